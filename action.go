@@ -0,0 +1,29 @@
+package blackjack
+
+// Action is a decision a player makes for the active hand.
+type Action int
+
+const (
+	ActionHit Action = iota
+	ActionStand
+	ActionDoubleDown
+	ActionSplit
+	ActionSurrender
+)
+
+func (a Action) String() string {
+	switch a {
+	case ActionHit:
+		return "hit"
+	case ActionStand:
+		return "stand"
+	case ActionDoubleDown:
+		return "double down"
+	case ActionSplit:
+		return "split"
+	case ActionSurrender:
+		return "surrender"
+	default:
+		return "unknown"
+	}
+}
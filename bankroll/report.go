@@ -0,0 +1,192 @@
+package bankroll
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/Hydoc/blackjack"
+)
+
+// Config describes the analysis.
+type Config struct {
+	// Bankroll is the money the sessions start with.
+	Bankroll blackjack.Money
+	// RoundsPerHour is the pace of the table for the hourly values. Zero plays 100 rounds an hour.
+	RoundsPerHour int
+	// Percentiles are the trajectories to report, from 0 to 100.
+	Percentiles []float64
+}
+
+// Report holds the analytics of sessions. Money is counted in minor units.
+type Report struct {
+	Sessions   int             `json:"sessions"`
+	Rounds     int             `json:"rounds"`
+	Bankroll   blackjack.Money `json:"bankroll"`
+	AverageBet float64         `json:"average_bet"`
+	// EV is the expected win of a round and StdDev its standard deviation.
+	EV     float64 `json:"ev"`
+	StdDev float64 `json:"std_dev"`
+	// N0 is the number of rounds after which the expected win equals one standard deviation, 0 without an advantage.
+	N0           float64 `json:"n0"`
+	HourlyWin    float64 `json:"hourly_win"`
+	HourlyStdDev float64 `json:"hourly_std_dev"`
+	// RiskOfRuin is the probability to lose the whole bankroll playing on forever, estimated from EV and StdDev.
+	RiskOfRuin float64 `json:"risk_of_ruin"`
+	// Ruined is the share of the sessions which lost the whole bankroll.
+	Ruined       float64      `json:"ruined"`
+	Trajectories []Trajectory `json:"trajectories"`
+}
+
+// Trajectory is the balance of the bankroll after every round at a percentile of the sessions.
+type Trajectory struct {
+	Percentile float64           `json:"percentile"`
+	Balance    []blackjack.Money `json:"balance"`
+}
+
+// Analyze computes the report of the sessions. A session which ended before the longest one keeps its final balance.
+func Analyze(sessions []Session, cfg Config) Report {
+	perHour := cfg.RoundsPerHour
+	if perHour == 0 {
+		perHour = 100
+	}
+
+	r := Report{
+		Sessions: len(sessions),
+		Bankroll: cfg.Bankroll,
+	}
+
+	var bets, sum, sumSquares float64
+	longest := 0
+	for _, s := range sessions {
+		for _, round := range s {
+			bets += float64(round.Bet)
+			sum += float64(round.Net)
+			sumSquares += float64(round.Net) * float64(round.Net)
+		}
+		r.Rounds += len(s)
+		longest = max(longest, len(s))
+	}
+	if r.Rounds == 0 {
+		return r
+	}
+
+	n := float64(r.Rounds)
+	r.AverageBet = bets / n
+	r.EV = sum / n
+	r.StdDev = math.Sqrt(max(sumSquares/n-r.EV*r.EV, 0))
+	r.HourlyWin = r.EV * float64(perHour)
+	r.HourlyStdDev = r.StdDev * math.Sqrt(float64(perHour))
+
+	r.RiskOfRuin = 1
+	if r.EV > 0 {
+		r.N0 = r.StdDev * r.StdDev / (r.EV * r.EV)
+		r.RiskOfRuin = math.Exp(-2 * r.EV * float64(cfg.Bankroll) / (r.StdDev * r.StdDev))
+	}
+
+	balances := make([][]blackjack.Money, len(sessions))
+	ruined := 0
+	for i, s := range sessions {
+		balances[i] = trajectory(s, cfg.Bankroll, longest)
+		if slices.Min(balances[i]) <= 0 {
+			ruined++
+		}
+	}
+	r.Ruined = float64(ruined) / float64(len(sessions))
+
+	for _, p := range cfg.Percentiles {
+		r.Trajectories = append(r.Trajectories, percentile(balances, p))
+	}
+	return r
+}
+
+// trajectory returns the balance after every round of the session, padded with the final balance to the length.
+func trajectory(s Session, bankroll blackjack.Money, length int) []blackjack.Money {
+	out := make([]blackjack.Money, length)
+	balance := bankroll
+	for i := range out {
+		if i < len(s) {
+			balance += s[i].Net
+		}
+		out[i] = balance
+	}
+	return out
+}
+
+// percentile returns the trajectory of the nearest-rank percentile of the balances after every round.
+func percentile(balances [][]blackjack.Money, p float64) Trajectory {
+	t := Trajectory{Percentile: p}
+	if len(balances) == 0 {
+		return t
+	}
+
+	rank := int(math.Ceil(p/100*float64(len(balances)))) - 1
+	rank = min(max(rank, 0), len(balances)-1)
+
+	column := make([]blackjack.Money, len(balances))
+	t.Balance = make([]blackjack.Money, len(balances[0]))
+	for round := range t.Balance {
+		for i := range balances {
+			column[i] = balances[i][round]
+		}
+		slices.Sort(column)
+		t.Balance[round] = column[rank]
+	}
+	return t
+}
+
+// WriteJSON writes the report as JSON.
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteCSV writes the values of the report without the trajectories as a header and a single record, so reports
+// of several rule sets or strategies can be appended to one another.
+func (r Report) WriteCSV(w io.Writer) error {
+	f := func(v float64) string {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{
+		"sessions", "rounds", "bankroll", "average_bet", "ev", "std_dev", "n0",
+		"hourly_win", "hourly_std_dev", "risk_of_ruin", "ruined",
+	})
+	cw.Write([]string{
+		strconv.Itoa(r.Sessions), strconv.Itoa(r.Rounds), strconv.FormatInt(int64(r.Bankroll), 10), f(r.AverageBet),
+		f(r.EV), f(r.StdDev), f(r.N0), f(r.HourlyWin), f(r.HourlyStdDev), f(r.RiskOfRuin), f(r.Ruined),
+	})
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteTrajectoriesCSV writes the trajectories with a record per round and a column per percentile.
+func (r Report) WriteTrajectoriesCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"round"}
+	for _, t := range r.Trajectories {
+		header = append(header, "p"+strconv.FormatFloat(t.Percentile, 'g', -1, 64))
+	}
+	cw.Write(header)
+
+	rounds := 0
+	if len(r.Trajectories) > 0 {
+		rounds = len(r.Trajectories[0].Balance)
+	}
+	for round := range rounds {
+		record := []string{strconv.Itoa(round + 1)}
+		for _, t := range r.Trajectories {
+			record = append(record, strconv.FormatInt(int64(t.Balance[round]), 10))
+		}
+		cw.Write(record)
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,170 @@
+package bankroll
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/blackjack/sim"
+	"github.com/Hydoc/blackjack/strategy"
+)
+
+func session(nets ...blackjack.Money) Session {
+	s := make(Session, len(nets))
+	for i, net := range nets {
+		s[i] = Round{Bet: 10, Net: net}
+	}
+	return s
+}
+
+func TestAnalyze(t *testing.T) {
+	sessions := []Session{
+		session(10, 10, -10, 10),
+		session(-10, -10, -10),
+		session(10, -10, 15, 10),
+	}
+
+	got := Analyze(sessions, Config{Bankroll: 30, RoundsPerHour: 50, Percentiles: []float64{0, 50, 100}})
+
+	ev := 15.0 / 11
+	stdDev := math.Sqrt(1225.0/11 - ev*ev)
+	want := Report{
+		Sessions:     3,
+		Rounds:       11,
+		Bankroll:     30,
+		AverageBet:   10,
+		EV:           ev,
+		StdDev:       stdDev,
+		N0:           stdDev * stdDev / (ev * ev),
+		HourlyWin:    50 * ev,
+		HourlyStdDev: math.Sqrt(50) * stdDev,
+		RiskOfRuin:   math.Exp(-2 * ev * 30 / (stdDev * stdDev)),
+		Ruined:       1.0 / 3,
+		Trajectories: []Trajectory{
+			{Percentile: 0, Balance: []blackjack.Money{20, 10, 0, 0}},
+			{Percentile: 50, Balance: []blackjack.Money{40, 30, 40, 50}},
+			{Percentile: 100, Balance: []blackjack.Money{40, 50, 45, 55}},
+		},
+	}
+
+	if got.Sessions != want.Sessions || got.Rounds != want.Rounds || got.Ruined != want.Ruined {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+	for name, pair := range map[string][2]float64{
+		"average bet":    {want.AverageBet, got.AverageBet},
+		"ev":             {want.EV, got.EV},
+		"std dev":        {want.StdDev, got.StdDev},
+		"n0":             {want.N0, got.N0},
+		"hourly win":     {want.HourlyWin, got.HourlyWin},
+		"hourly std dev": {want.HourlyStdDev, got.HourlyStdDev},
+		"risk of ruin":   {want.RiskOfRuin, got.RiskOfRuin},
+	} {
+		if math.Abs(pair[0]-pair[1]) > 1e-9 {
+			t.Errorf("%s: want %#v, got %#v", name, pair[0], pair[1])
+		}
+	}
+	if !reflect.DeepEqual(got.Trajectories, want.Trajectories) {
+		t.Errorf("want %#v, got %#v", want.Trajectories, got.Trajectories)
+	}
+}
+
+func TestAnalyze_NoAdvantage(t *testing.T) {
+	got := Analyze([]Session{session(-10, 10, -10)}, Config{Bankroll: 100})
+
+	if got.RiskOfRuin != 1 || got.N0 != 0 {
+		t.Errorf("want a certain ruin and no N0, got %#v and %#v", got.RiskOfRuin, got.N0)
+	}
+	if got.HourlyWin != 100*got.EV {
+		t.Errorf("want 100 rounds an hour by default, got %#v", got.HourlyWin)
+	}
+}
+
+func TestAnalyze_Empty(t *testing.T) {
+	got := Analyze(nil, Config{Bankroll: 100, Percentiles: []float64{50}})
+
+	want := Report{Bankroll: 100}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+}
+
+func TestAnalyze_Simulation(t *testing.T) {
+	rules := blackjack.Rules{Decks: 6, DoubleOn: blackjack.DoubleAnyTwo, DoubleAfterSplit: true, Peek: true}
+
+	sessions := make([]Session, 20)
+	for i := range sessions {
+		_, err := sim.Run(sim.Config{
+			Rules:    rules,
+			Strategy: strategy.New(rules),
+			Rounds:   500,
+			Bankroll: 50 * blackjack.Unit,
+			Seed:     uint64(i + 1),
+			OnRound:  sessions[i].Add,
+		})
+		if err != nil {
+			t.Fatalf("want nil, got %v", err)
+		}
+	}
+
+	got := Analyze(sessions, Config{Bankroll: 50 * blackjack.Unit, Percentiles: []float64{5, 50, 95}})
+	if got.StdDev < 1.0*float64(blackjack.Unit) || got.StdDev > 1.3*float64(blackjack.Unit) {
+		t.Errorf("want a standard deviation of about 1.15 units, got %.2f", got.StdDev)
+	}
+	if len(got.Trajectories) != 3 || len(got.Trajectories[0].Balance) != 500 {
+		t.Fatalf("want 3 trajectories of 500 rounds, got %#v", got.Trajectories)
+	}
+	for round := range 500 {
+		if got.Trajectories[0].Balance[round] > got.Trajectories[2].Balance[round] {
+			t.Fatalf("want the 5th percentile below the 95th in round %d", round+1)
+		}
+	}
+}
+
+func TestReport_WriteJSON(t *testing.T) {
+	r := Analyze([]Session{session(10, -10)}, Config{Bankroll: 30, Percentiles: []float64{50}})
+
+	var b bytes.Buffer
+	if err := r.WriteJSON(&b); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	var got Report
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+	if !reflect.DeepEqual(got, r) {
+		t.Errorf("want %#v, got %#v", r, got)
+	}
+}
+
+func TestReport_WriteCSV(t *testing.T) {
+	r := Report{Sessions: 1, Rounds: 2, Bankroll: 30, AverageBet: 10, EV: 0.5, StdDev: 10, RiskOfRuin: 1}
+
+	var b bytes.Buffer
+	if err := r.WriteCSV(&b); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	want := "sessions,rounds,bankroll,average_bet,ev,std_dev,n0,hourly_win,hourly_std_dev,risk_of_ruin,ruined\n" +
+		"1,2,30,10,0.5,10,0,0,0,1,0\n"
+	if got := b.String(); got != want {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+}
+
+func TestReport_WriteTrajectoriesCSV(t *testing.T) {
+	r := Analyze([]Session{session(10, -10), session(-10, -10)}, Config{Bankroll: 30, Percentiles: []float64{0, 100}})
+
+	var b bytes.Buffer
+	if err := r.WriteTrajectoriesCSV(&b); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	want := "round,p0,p100\n1,20,40\n2,10,30\n"
+	if got := b.String(); got != want {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+}
@@ -0,0 +1,65 @@
+// Package bankroll analyzes the rounds of simulated or real sessions: the risk of ruin of a bankroll, the
+// standard deviation and expectation per round, N0, the hourly win and percentile bankroll trajectories. Reports
+// are exported as CSV and JSON.
+package bankroll
+
+import (
+	"slices"
+
+	"github.com/Hydoc/blackjack"
+)
+
+// Round is the result of one round of a player.
+type Round struct {
+	// Bet is the main bet of the round.
+	Bet blackjack.Money
+	// Net is what the player won in the round, negative if the player lost.
+	Net blackjack.Money
+}
+
+// Session holds the rounds of a player in the order they were played.
+type Session []Round
+
+// Add appends a round to the session. Its signature matches sim.Config's OnRound:
+//
+//	var s bankroll.Session
+//	sim.Run(sim.Config{..., OnRound: s.Add})
+func (s *Session) Add(bet, net blackjack.Money) {
+	*s = append(*s, Round{Bet: bet, Net: net})
+}
+
+// FromLedger returns the session of the player with the given ID, see blackjack.Player.ID, at a real table from
+// its ledger. Every round the player wagered in is a round of the session, bets which were refunded before the
+// round was dealt do not count. A nil ledger, see blackjack.WithLedger, returns an empty session.
+func FromLedger(ledger *blackjack.Ledger, player uint64) Session {
+	if ledger == nil {
+		return nil
+	}
+
+	var s Session
+	last := -1
+	for _, e := range ledger.Entries() {
+		if e.PlayerID != player {
+			continue
+		}
+		if len(s) == 0 || e.Round != last {
+			s = append(s, Round{})
+			last = e.Round
+		}
+
+		r := &s[len(s)-1]
+		switch {
+		case e.Kind == blackjack.Credit && e.Reason == blackjack.ReasonRefund:
+			r.Bet -= e.Amount
+			r.Net += e.Amount
+		case e.Kind == blackjack.Credit:
+			r.Net += e.Amount
+		case e.Reason == blackjack.ReasonBet:
+			r.Bet += e.Amount
+			r.Net -= e.Amount
+		default:
+			r.Net -= e.Amount
+		}
+	}
+	return slices.DeleteFunc(s, func(r Round) bool { return r.Bet == 0 })
+}
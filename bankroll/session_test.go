@@ -0,0 +1,87 @@
+package bankroll
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Hydoc/blackjack"
+)
+
+func TestSession_Add(t *testing.T) {
+	var s Session
+	s.Add(10*blackjack.Unit, -10*blackjack.Unit)
+	s.Add(10*blackjack.Unit, 15*blackjack.Unit)
+
+	want := Session{{Bet: 10 * blackjack.Unit, Net: -10 * blackjack.Unit}, {Bet: 10 * blackjack.Unit, Net: 15 * blackjack.Unit}}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("want %#v, got %#v", want, s)
+	}
+}
+
+func TestFromLedger(t *testing.T) {
+	table := blackjack.New(blackjack.WithRules(blackjack.Rules{Decks: 1}))
+	alice := blackjack.NewPlayer(100*blackjack.Unit, blackjack.WithName("alice"))
+	// a namesake of alice, the ledger keeps the two apart
+	bob := blackjack.NewPlayer(100*blackjack.Unit, blackjack.WithName("alice"))
+	table.Join(alice)
+	table.Join(bob)
+
+	for _, bet := range []blackjack.Money{10 * blackjack.Unit, 20 * blackjack.Unit} {
+		table.Bet(alice, bet)
+		table.Bet(bob, 5*blackjack.Unit)
+		table.Start()
+		for table.InProgress() {
+			table.Stand()
+		}
+	}
+
+	got := FromLedger(table.Ledger(), alice.ID())
+	if len(got) != 2 || got[0].Bet != 10*blackjack.Unit || got[1].Bet != 20*blackjack.Unit {
+		t.Fatalf("want two rounds betting 10.00 and 20.00, got %#v", got)
+	}
+
+	var net blackjack.Money
+	for _, r := range got {
+		net += r.Net
+	}
+	if want := table.Ledger().Net(alice.ID()); net != want {
+		t.Errorf("want %s, got %s", want, net)
+	}
+}
+
+func TestFromLedger_Refunded(t *testing.T) {
+	table := blackjack.New(blackjack.WithRules(blackjack.Rules{Decks: 1}))
+	alice := blackjack.NewPlayer(100*blackjack.Unit, blackjack.WithName("alice"))
+	bob := blackjack.NewPlayer(100*blackjack.Unit, blackjack.WithName("bob"))
+	table.Join(alice)
+	table.Join(bob)
+
+	// alice replaces her bet before the round, bob replaces his bet and leaves before it is dealt
+	table.Bet(alice, 10*blackjack.Unit)
+	table.Bet(alice, 20*blackjack.Unit)
+	table.Bet(bob, 10*blackjack.Unit)
+	table.Bet(bob, 20*blackjack.Unit)
+	table.Leave(bob)
+	table.Start()
+	for table.InProgress() {
+		table.Stand()
+	}
+
+	if got := FromLedger(table.Ledger(), alice.ID()); len(got) != 1 || got[0].Bet != 20*blackjack.Unit {
+		t.Errorf("want one round betting 20.00, got %#v", got)
+	}
+
+	if got := FromLedger(table.Ledger(), bob.ID()); len(got) != 0 {
+		t.Errorf("want no rounds, got %#v", got)
+	}
+}
+
+func TestFromLedger_NilLedger(t *testing.T) {
+	table := blackjack.New(blackjack.WithLedger(nil))
+	player := blackjack.NewPlayer(100 * blackjack.Unit)
+	table.Join(player)
+
+	if got := FromLedger(table.Ledger(), player.ID()); len(got) != 0 {
+		t.Errorf("want no rounds, got %#v", got)
+	}
+}
@@ -0,0 +1,115 @@
+package blackjack
+
+import (
+	"slices"
+
+	"github.com/Hydoc/deck"
+)
+
+// Bot decides the actions of a player bound to it with Bind. It gets a snapshot of the table and the legal actions
+// of the active hand, the hand itself are the cards of the State's TurnPlayer.
+type Bot interface {
+	Action(state State, legal []Action) Action
+}
+
+// BotFunc adapts a function to a Bot.
+type BotFunc func(state State, legal []Action) Action
+
+func (f BotFunc) Action(state State, legal []Action) Action {
+	return f(state, legal)
+}
+
+// Coached returns a Bot which always takes the advice of the coach, for example a strategy.Strategy.
+func Coached(coach Coach) Bot {
+	return BotFunc(func(state State, legal []Action) Action {
+		var up deck.Card
+		if len(state.DealerCards) > 0 {
+			up = state.DealerCards[0]
+		}
+		action, _ := coach.Advise(state.TurnPlayer.Cards(), up, legal)
+		return action
+	})
+}
+
+// Bind binds the seated player to the bot. Whenever it is the player's turn the table asks the bot for the action
+// and applies it, so the player never has to act. Bets are still placed using Bet.
+// It returns ErrNotAtTable if the player did not join.
+func (t *Table) Bind(p *Player, bot Bot) error {
+	if !t.isSeated(p) {
+		return ErrNotAtTable
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.bots == nil {
+		t.bots = make(map[*Player]Bot)
+	}
+	t.bots[p] = bot
+	return nil
+}
+
+// Unbind lets the player act on its own again. It does nothing if the player was not bound to a bot.
+func (t *Table) Unbind(p *Player) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.bots, p)
+}
+
+// bot returns the bot the player is bound to.
+func (t *Table) bot(p *Player) (Bot, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bot, ok := t.bots[p]
+	return bot, ok
+}
+
+// playBots lets the bots act as long as it is the turn of a player bound to one. The actions of the bots end up
+// here again once the turn changes, which only the outermost call acts on.
+// An action which is not legal is replaced by standing, or by the first legal action if standing is not allowed.
+func (t *Table) playBots() error {
+	if t.botsPlaying {
+		return nil
+	}
+	t.botsPlaying = true
+	defer func() { t.botsPlaying = false }()
+
+	for t.turnPlayer != nil {
+		bot, ok := t.bot(t.turnPlayer)
+		if !ok {
+			return nil
+		}
+
+		legal := t.LegalActions()
+		action := bot.Action(t.State(), legal)
+		if !slices.Contains(legal, action) {
+			action = ActionStand
+			if !slices.Contains(legal, action) && len(legal) > 0 {
+				action = legal[0]
+			}
+		}
+
+		if err := t.apply(action); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply takes the action for the turnPlayer.
+func (t *Table) apply(action Action) error {
+	switch action {
+	case ActionHit:
+		return t.Hit()
+	case ActionDoubleDown:
+		return t.DoubleDown()
+	case ActionSplit:
+		return t.Split()
+	case ActionSurrender:
+		return t.Surrender()
+	default:
+		return t.Stand()
+	}
+}
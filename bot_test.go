@@ -0,0 +1,161 @@
+package blackjack
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Hydoc/deck"
+)
+
+// botDeck deals 10 and 2 to the first player, 9 and 3 to the second and 7 and 8 to the dealer.
+// The cards after the deal are 5, 6, 4 and 10.
+func botDeck() []deck.Card {
+	return stack(
+		deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+		deck.Card{Rank: deck.Nine, Suit: deck.Club},
+		deck.Card{Rank: deck.Seven, Suit: deck.Spade},
+		deck.Card{Rank: deck.Two, Suit: deck.Heart},
+		deck.Card{Rank: deck.Three, Suit: deck.Club},
+		deck.Card{Rank: deck.Eight, Suit: deck.Spade},
+		deck.Card{Rank: deck.Five, Suit: deck.Diamond},
+		deck.Card{Rank: deck.Six, Suit: deck.Diamond},
+		deck.Card{Rank: deck.Four, Suit: deck.Diamond},
+		deck.Card{Rank: deck.Ten, Suit: deck.Diamond},
+	)
+}
+
+// recordingBot hits until it holds at least the total and records the legal actions it was asked with.
+type recordingBot struct {
+	standOn int
+	legal   [][]Action
+}
+
+func (b *recordingBot) Action(state State, legal []Action) Action {
+	b.legal = append(b.legal, legal)
+	if Evaluate(state.TurnPlayer.Cards()).Total >= b.standOn {
+		return ActionStand
+	}
+	return ActionHit
+}
+
+func TestTable_Bind(t *testing.T) {
+	table := New()
+	table.deck = botDeck()
+	robot := NewPlayer(100*Unit, WithName("robot"))
+	human := NewPlayer(100*Unit, WithName("human"))
+	table.Join(robot)
+	table.Join(human)
+
+	bot := &recordingBot{standOn: 17}
+	if err := table.Bind(robot, bot); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	table.Bet(robot, 10*Unit)
+	table.Bet(human, 10*Unit)
+	if err := table.Start(); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	// the robot hit 5 on 12 and stood on 17 before the human's turn
+	if table.turnPlayer != human {
+		t.Fatalf("want the human's turn, got %#v", table.turnPlayer)
+	}
+	want := [][]Action{
+		{ActionHit, ActionStand},
+		{ActionHit, ActionStand},
+	}
+	if !reflect.DeepEqual(bot.legal, want) {
+		t.Errorf("want %#v, got %#v", want, bot.legal)
+	}
+	if got := Evaluate(robot.hands.first.cards).Total; got != 17 {
+		t.Errorf("want %#v, got %#v", 17, got)
+	}
+
+	table.Stand()
+	if !table.IsDone() {
+		t.Errorf("want the round to be done")
+	}
+}
+
+func TestTable_Bind_LastSeat(t *testing.T) {
+	table := New()
+	table.deck = botDeck()
+	human := NewPlayer(100 * Unit)
+	robot := NewPlayer(100 * Unit)
+	table.Join(human)
+	table.Join(robot)
+	table.Bind(robot, BotFunc(func(State, []Action) Action { return ActionStand }))
+
+	table.Bet(human, 10*Unit)
+	table.Bet(robot, 10*Unit)
+	table.Start()
+	if table.turnPlayer != human {
+		t.Fatalf("want the human's turn, got %#v", table.turnPlayer)
+	}
+
+	if err := table.Stand(); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+	if !table.IsDone() {
+		t.Errorf("want the robot to finish the round")
+	}
+	if got := len(robot.hands.first.cards); got != 2 {
+		t.Errorf("want %#v, got %#v", 2, got)
+	}
+}
+
+func TestTable_Bind_IllegalAction(t *testing.T) {
+	table := New()
+	table.deck = botDeck()
+	robot := NewPlayer(100 * Unit)
+	table.Join(robot)
+	table.Bind(robot, BotFunc(func(State, []Action) Action { return ActionSplit }))
+
+	table.Bet(robot, 10*Unit)
+	if err := table.Start(); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	if !table.IsDone() || len(robot.hands.first.cards) != 2 {
+		t.Errorf("want the robot to stand instead of splitting, got %#v", robot.hands.first.cards)
+	}
+}
+
+func TestTable_Bind_Coached(t *testing.T) {
+	table := New()
+	table.deck = botDeck()
+	robot := NewPlayer(100 * Unit)
+	table.Join(robot)
+	table.Bind(robot, Coached(alwaysStand{}))
+
+	table.Bet(robot, 10*Unit)
+	table.Start()
+
+	if !table.IsDone() || len(robot.hands.first.cards) != 2 {
+		t.Errorf("want the robot to stand, got %#v", robot.hands.first.cards)
+	}
+}
+
+func TestTable_Unbind(t *testing.T) {
+	table := New()
+	robot := NewPlayer(100 * Unit)
+
+	if err := table.Bind(robot, Coached(alwaysStand{})); !errors.Is(err, ErrNotAtTable) {
+		t.Errorf("want %v, got %v", ErrNotAtTable, err)
+	}
+
+	table.Join(robot)
+	table.Bind(robot, Coached(alwaysStand{}))
+	table.Unbind(robot)
+	if _, ok := table.bot(robot); ok {
+		t.Errorf("want no bot after Unbind")
+	}
+
+	table.Bind(robot, Coached(alwaysStand{}))
+	table.Leave(robot)
+	if _, ok := table.bot(robot); ok {
+		t.Errorf("want no bot after Leave")
+	}
+}
@@ -0,0 +1,119 @@
+// Command bots seats external bots speaking the line protocol of package protocol at a table and plays rounds
+// until every bot is out of money or the rounds are played. Every argument is the command line of one bot:
+//
+//	bots -rounds 1000 "python3 bot.py" ./other-bot
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/blackjack/protocol"
+)
+
+func main() {
+	rounds := flag.Int("rounds", 100, "rounds to play")
+	decks := flag.Int("decks", 6, "decks in the shoe")
+	bet := flag.Int64("bet", 10, "bet of every round in units")
+	balance := flag.Int64("balance", 1000, "balance every bot starts with in units")
+	timeout := flag.Duration("timeout", time.Second, "time a bot has to answer")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] command...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 || flag.NArg() > 7 || slices.ContainsFunc(flag.Args(), isBlank) {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	rules := blackjack.DefaultRules()
+	rules.Decks = *decks
+	err := run(
+		blackjack.New(blackjack.WithRules(rules)),
+		flag.Args(),
+		*rounds,
+		blackjack.Money(*bet)*blackjack.Unit,
+		blackjack.Money(*balance)*blackjack.Unit,
+		*timeout,
+	)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run seats a bot for every command and plays the rounds.
+func run(table *blackjack.Table, commands []string, rounds int, bet, balance blackjack.Money, timeout time.Duration) error {
+	var players []*blackjack.Player
+	var bots []*protocol.Process
+	defer func() {
+		for _, bot := range bots {
+			bot.Close()
+		}
+	}()
+
+	for _, command := range commands {
+		args := strings.Fields(command)
+		if len(args) == 0 {
+			return fmt.Errorf("empty command of bot %d", len(bots)+1)
+		}
+		bot, err := protocol.Start(timeout, args[0], args[1:]...)
+		if err != nil {
+			return fmt.Errorf("%s: %w", command, err)
+		}
+		bots = append(bots, bot)
+
+		p := blackjack.NewPlayer(balance, blackjack.WithName(command))
+		if err := table.Join(p); err != nil {
+			return err
+		}
+		if err := table.Bind(p, bot); err != nil {
+			return err
+		}
+		players = append(players, p)
+	}
+
+	played := 0
+	for range rounds {
+		betting := 0
+		for _, p := range players {
+			if p.Balance() < bet {
+				table.Leave(p)
+				continue
+			}
+			if err := table.Bet(p, bet); err != nil {
+				return fmt.Errorf("%s: %w", p.Name, err)
+			}
+			betting++
+		}
+		if betting == 0 {
+			break
+		}
+
+		if err := table.Start(); err != nil {
+			return err
+		}
+		played++
+	}
+
+	fmt.Printf("rounds %d\n", played)
+	for i, p := range players {
+		status := "ok"
+		if err := bots[i].Err(); err != nil {
+			status = err.Error()
+		}
+		fmt.Printf("%-30s balance %10s  %s\n", p.Name, p.Balance(), status)
+	}
+	return nil
+}
+
+func isBlank(command string) bool {
+	return strings.TrimSpace(command) == ""
+}
@@ -0,0 +1,88 @@
+// Command sim plays millions of rounds of blackjack with basic strategy and reports the house edge for the rules.
+// With a betting strategy and a bankroll it compares how the bankroll does, the count based strategies count Hi-Lo.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/blackjack/counting"
+	"github.com/Hydoc/blackjack/sim"
+	"github.com/Hydoc/blackjack/strategy"
+)
+
+func main() {
+	rounds := flag.Int("rounds", 1_000_000, "rounds to play")
+	decks := flag.Int("decks", 6, "decks in the shoe")
+	hitSoft17 := flag.Bool("h17", false, "dealer hits soft 17")
+	das := flag.Bool("das", true, "double after split")
+	surrender := flag.Bool("surrender", false, "late surrender")
+	peek := flag.Bool("peek", true, "dealer peeks for black jack")
+	sixToFive := flag.Bool("6to5", false, "black jack pays 6 to 5")
+	anyTwo := flag.Bool("any-two", true, "double on any two cards")
+	seed := flag.Uint64("seed", 0, "seed of the shuffles, 0 seeds randomly")
+	betting := flag.String("betting", "flat", "betting strategy: flat, martingale, paroli, 1326, kelly or spread")
+	bankroll := flag.Int64("bankroll", 0, "bankroll in units, 0 for an unlimited one")
+	flag.Parse()
+
+	rules := blackjack.Rules{
+		Decks:            *decks,
+		DealerHitsSoft17: *hitSoft17,
+		DoubleAfterSplit: *das,
+		Surrender:        *surrender,
+		Peek:             *peek,
+	}
+	if *sixToFive {
+		rules.BlackJackPayout = blackjack.SixToFive
+	}
+	if *anyTwo {
+		rules.DoubleOn = blackjack.DoubleAnyTwo
+	}
+
+	counter := counting.New(counting.HiLo, rules)
+	bets, ok := map[string]sim.BetStrategy{
+		"flat":       sim.Flat(blackjack.Unit),
+		"martingale": sim.Martingale(blackjack.Unit, 0),
+		"paroli":     sim.Paroli(blackjack.Unit),
+		"1326":       sim.OneThreeTwoSix(blackjack.Unit),
+		"kelly":      sim.Kelly(blackjack.Unit, strategy.HouseEdge(rules), 0.5),
+		"spread":     sim.Spread(blackjack.Unit, 1, 1, 2, 4, 8, 12),
+	}[*betting]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown betting strategy %q\n", *betting)
+		os.Exit(2)
+	}
+
+	start := time.Now()
+	result, err := sim.Run(sim.Config{
+		Rules:       rules,
+		Strategy:    strategy.New(rules),
+		Rounds:      *rounds,
+		Seed:        *seed,
+		BetStrategy: bets,
+		Counter:     counter,
+		Observers:   []blackjack.Observer{counter},
+		Bankroll:    blackjack.Money(*bankroll) * blackjack.Unit,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("rounds       %d in %s\n", result.Rounds, time.Since(start).Round(time.Millisecond))
+	fmt.Printf("hands        %d\n", result.Hands)
+	fmt.Printf("house edge   %.3f%%\n", 100*result.HouseEdge())
+	fmt.Printf("analytic     %.3f%%\n", 100*strategy.HouseEdge(rules))
+	fmt.Printf("std dev      %.3f\n", result.StdDev())
+	fmt.Printf("wins         %.2f%%\n", 100*result.WinRate())
+	fmt.Printf("pushes       %.2f%%\n", 100*result.PushRate())
+	fmt.Printf("losses       %.2f%%\n", 100*result.LossRate())
+	fmt.Printf("black jacks  %.2f%%\n", 100*result.BlackJackRate())
+	fmt.Printf("net          %s\n", result.Net)
+	if *bankroll > 0 {
+		fmt.Printf("bankroll     %s, ruined %t\n", result.Bankroll, result.Ruined)
+	}
+}
@@ -0,0 +1,110 @@
+package counting
+
+import (
+	"sync"
+
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/blackjack/ev"
+	"github.com/Hydoc/deck"
+)
+
+// Counter counts the cards seen at a table with a System. It implements blackjack.Observer:
+//
+//	counter := counting.New(counting.HiLo, rules)
+//	table := blackjack.New(blackjack.WithRules(rules), blackjack.WithObserver(counter))
+//
+// An unbalanced system starts the running count of every shoe at the initial running count, which puts the pivot
+// of KO at +4 whatever the amount of decks.
+type Counter struct {
+	mu sync.Mutex
+
+	system    System
+	shoe      ev.Shoe
+	decks     int
+	running   int
+	remaining ev.Shoe
+}
+
+// New creates a pointer to a Counter for a shoe of the rules, usually the ones a Table was created with.
+func New(system System, rules blackjack.Rules) *Counter {
+	c := &Counter{
+		system: system,
+		shoe:   ev.NewShoe(rules),
+		decks:  rules.Decks,
+	}
+	if c.decks == 0 {
+		c.decks = 6
+	}
+	c.reset()
+	return c
+}
+
+// System returns the system the counter counts with.
+func (c *Counter) System() System {
+	return c.system
+}
+
+// Dealt counts the card and removes it from the remaining composition.
+func (c *Counter) Dealt(card deck.Card) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.remaining = c.remaining.Remove(card)
+	c.running += c.system.Tags[ev.Value(card)]
+}
+
+// Shuffled starts counting a fresh shoe.
+func (c *Counter) Shuffled(int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.reset()
+}
+
+func (c *Counter) reset() {
+	c.remaining = c.shoe
+	// an unbalanced system starts below 0, so that a whole shoe counts up to the imbalance of a single deck
+	c.running = -c.system.Imbalance() * (c.decks - 1)
+}
+
+// RunningCount returns the sum of the tags of every card seen since the shuffle.
+func (c *Counter) RunningCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.running
+}
+
+// DecksRemaining returns the amount of decks left in the shoe.
+func (c *Counter) DecksRemaining() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.decksRemaining()
+}
+
+func (c *Counter) decksRemaining() float64 {
+	return float64(c.remaining.Size()*c.decks) / float64(c.shoe.Size())
+}
+
+// TrueCount returns the running count per deck left. It is the running count once no card is left.
+// The true count is meant for balanced systems, an unbalanced one like KO bets on the running count.
+func (c *Counter) TrueCount() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	decks := c.decksRemaining()
+	if c.remaining.Size() == 0 {
+		return float64(c.running)
+	}
+	return float64(c.running) / decks
+}
+
+// Remaining returns the composition of the cards not seen since the shuffle, for example to compute exact
+// expected values with package ev.
+func (c *Counter) Remaining() ev.Shoe {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.remaining
+}
@@ -0,0 +1,123 @@
+package counting
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/blackjack/ev"
+	"github.com/Hydoc/deck"
+)
+
+func cards(ranks ...deck.Rank) []deck.Card {
+	c := make([]deck.Card, len(ranks))
+	for i, r := range ranks {
+		c[i] = deck.Card{Rank: r}
+	}
+	return c
+}
+
+func TestCounter_RunningCount(t *testing.T) {
+	seen := cards(deck.Two, deck.Five, deck.Seven, deck.Nine, deck.King, deck.Ace, deck.Four, deck.Six)
+
+	tests := []struct {
+		system System
+		decks  int
+		want   int
+	}{
+		{system: HiLo, decks: 6, want: 2},
+		{system: KO, decks: 6, want: -17},
+		{system: KO, decks: 1, want: 3},
+		{system: HiOptI, decks: 6, want: 2},
+		{system: HiOptII, decks: 6, want: 5},
+		{system: OmegaII, decks: 6, want: 5},
+		{system: Zen, decks: 6, want: 5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.system.Name, func(t *testing.T) {
+			c := New(test.system, blackjack.Rules{Decks: test.decks})
+			for _, card := range seen {
+				c.Dealt(card)
+			}
+
+			if got := c.RunningCount(); got != test.want {
+				t.Errorf("want %#v, got %#v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestCounter_TrueCount(t *testing.T) {
+	c := New(HiLo, blackjack.Rules{Decks: 2})
+	for _, r := range []deck.Rank{deck.Two, deck.Three, deck.Four, deck.Five, deck.Six} {
+		for range 4 {
+			c.Dealt(deck.Card{Rank: r})
+		}
+	}
+	for range 6 {
+		c.Dealt(deck.Card{Rank: deck.Seven})
+	}
+
+	if got := c.DecksRemaining(); got != 1.5 {
+		t.Errorf("want %#v, got %#v", 1.5, got)
+	}
+	want := 20 / 1.5
+	if got := c.TrueCount(); math.Abs(got-want) > 1e-9 {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+}
+
+func TestCounter_Shuffled(t *testing.T) {
+	rules := blackjack.Rules{Decks: 1}
+	c := New(KO, rules)
+	c.Dealt(deck.Card{Rank: deck.Two})
+	c.Shuffled(52)
+
+	if got := c.RunningCount(); got != 0 {
+		t.Errorf("want %#v, got %#v", 0, got)
+	}
+	if got := c.Remaining(); got != ev.NewShoe(rules) {
+		t.Errorf("want %#v, got %#v", ev.NewShoe(rules), got)
+	}
+}
+
+func TestCounter_Table(t *testing.T) {
+	rules := blackjack.Rules{Decks: 1, Peek: true}
+	c := New(HiLo, rules)
+	table := blackjack.New(
+		blackjack.WithRules(rules),
+		blackjack.WithRand(rand.New(rand.NewPCG(1, 2))),
+		blackjack.WithObserver(c),
+	)
+	player := blackjack.NewPlayer(100 * blackjack.Unit)
+	table.Join(player)
+	table.Bet(player, 10*blackjack.Unit)
+	if err := table.Start(); err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+
+	seen := append([]deck.Card{}, player.Cards()...)
+	up, _ := table.UpCard()
+	seen = append(seen, up)
+	if got, want := c.Remaining(), ev.NewShoe(rules).Remove(seen...); got != want {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+
+	for table.InProgress() {
+		table.Stand()
+	}
+	seen = append(seen[:len(seen)-1], table.State().DealerCards...)
+	if got, want := c.Remaining(), ev.NewShoe(rules).Remove(seen...); got != want {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+
+	running := 0
+	for _, card := range seen {
+		running += HiLo.Tags[ev.Value(card)]
+	}
+	if got := c.RunningCount(); got != running {
+		t.Errorf("want %#v, got %#v", running, got)
+	}
+}
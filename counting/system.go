@@ -0,0 +1,37 @@
+// Package counting tracks the cards seen at a blackjack.Table with the common card counting systems. A Counter is
+// registered as observer of the table and keeps the running count, the true count and the composition of the shoe
+// which is left.
+package counting
+
+// System is a card counting system. Tags holds the value every card adds to the running count by its value,
+// index 1 for the ace and 10 for every ten-valued card.
+type System struct {
+	Name string
+	Tags [11]int
+}
+
+var (
+	HiLo    = System{Name: "Hi-Lo", Tags: [11]int{0, -1, 1, 1, 1, 1, 1, 0, 0, 0, -1}}
+	KO      = System{Name: "KO", Tags: [11]int{0, -1, 1, 1, 1, 1, 1, 1, 0, 0, -1}}
+	HiOptI  = System{Name: "Hi-Opt I", Tags: [11]int{0, 0, 0, 1, 1, 1, 1, 0, 0, 0, -1}}
+	HiOptII = System{Name: "Hi-Opt II", Tags: [11]int{0, 0, 1, 1, 2, 2, 1, 1, 0, 0, -2}}
+	OmegaII = System{Name: "Omega II", Tags: [11]int{0, 0, 1, 1, 2, 2, 2, 1, 0, -1, -2}}
+	Zen     = System{Name: "Zen Count", Tags: [11]int{0, -1, 1, 1, 2, 2, 2, 1, 0, 0, -2}}
+)
+
+// Systems holds every system of the package.
+var Systems = []System{HiLo, KO, HiOptI, HiOptII, OmegaII, Zen}
+
+// Imbalance returns the running count after counting a whole deck, 0 for a balanced system.
+func (s System) Imbalance() int {
+	n := 16 * s.Tags[10]
+	for v := 1; v <= 9; v++ {
+		n += 4 * s.Tags[v]
+	}
+	return n
+}
+
+// Balanced reports whether the tags of a whole deck add up to 0.
+func (s System) Balanced() bool {
+	return s.Imbalance() == 0
+}
@@ -0,0 +1,29 @@
+package counting
+
+import "testing"
+
+func TestSystem_Imbalance(t *testing.T) {
+	tests := []struct {
+		system       System
+		wantBalanced bool
+		want         int
+	}{
+		{system: HiLo, wantBalanced: true},
+		{system: KO, want: 4},
+		{system: HiOptI, wantBalanced: true},
+		{system: HiOptII, wantBalanced: true},
+		{system: OmegaII, wantBalanced: true},
+		{system: Zen, wantBalanced: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.system.Name, func(t *testing.T) {
+			if got := test.system.Imbalance(); got != test.want {
+				t.Errorf("want %#v, got %#v", test.want, got)
+			}
+			if got := test.system.Balanced(); got != test.wantBalanced {
+				t.Errorf("want %#v, got %#v", test.wantBalanced, got)
+			}
+		})
+	}
+}
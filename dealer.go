@@ -14,9 +14,15 @@ func (d *Dealer) hit(card deck.Card) {
 	d.hand.hit(card)
 }
 
+// HitUntil17 draws from the cards until the dealer reaches at least 17 and returns the remaining cards.
 func (d *Dealer) HitUntil17(cards []deck.Card) []deck.Card {
+	return d.play(cards, false)
+}
+
+// play draws from the cards like HitUntil17, but keeps drawing on a soft 17 if hitSoft17 is set.
+func (d *Dealer) play(cards []deck.Card, hitSoft17 bool) []deck.Card {
 	remaining := cards
-	for d.hand.sum() < 16 {
+	for d.mustHit(hitSoft17) {
 		c, leftover := deck.Draw(1)(remaining)
 		d.hit(c[0])
 		remaining = leftover
@@ -24,8 +30,13 @@ func (d *Dealer) HitUntil17(cards []deck.Card) []deck.Card {
 	return remaining
 }
 
+func (d *Dealer) mustHit(hitSoft17 bool) bool {
+	score := Evaluate(d.hand.cards)
+	return score.Total < 17 || hitSoft17 && score.Total == 17 && score.Soft
+}
+
 func newDealer() *Dealer {
 	return &Dealer{
-		hand: newHand(make([]deck.Card, 0), true),
+		hand: newHand(make([]deck.Card, 0, cardsPerHand), true),
 	}
 }
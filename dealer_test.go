@@ -33,6 +33,40 @@ func TestDealer_HitUntil17(t *testing.T) {
 				{Rank: deck.Jack, Suit: deck.Heart},
 			},
 		},
+		{
+			name: "ten -> six -> five, hits on 16",
+			cards: []deck.Card{
+				{Rank: deck.Two, Suit: deck.Heart},
+				{Rank: deck.Five, Suit: deck.Spade},
+				{Rank: deck.Six, Suit: deck.Club},
+				{Rank: deck.Ten, Suit: deck.Diamond},
+			},
+			wantSum: 21,
+			wantCards: []deck.Card{
+				{Rank: deck.Ten, Suit: deck.Diamond},
+				{Rank: deck.Six, Suit: deck.Club},
+				{Rank: deck.Five, Suit: deck.Spade},
+			},
+			wantRemaining: []deck.Card{
+				{Rank: deck.Two, Suit: deck.Heart},
+			},
+		},
+		{
+			name: "ten -> seven, stands on hard 17",
+			cards: []deck.Card{
+				{Rank: deck.Two, Suit: deck.Heart},
+				{Rank: deck.Seven, Suit: deck.Club},
+				{Rank: deck.Ten, Suit: deck.Diamond},
+			},
+			wantSum: 17,
+			wantCards: []deck.Card{
+				{Rank: deck.Ten, Suit: deck.Diamond},
+				{Rank: deck.Seven, Suit: deck.Club},
+			},
+			wantRemaining: []deck.Card{
+				{Rank: deck.Two, Suit: deck.Heart},
+			},
+		},
 		{
 			name: "ace -> six",
 			cards: []deck.Card{
@@ -70,3 +104,41 @@ func TestDealer_HitUntil17(t *testing.T) {
 		})
 	}
 }
+
+func TestDealer_play(t *testing.T) {
+	tests := []struct {
+		name      string
+		cards     []deck.Card
+		hitSoft17 bool
+		wantSum   int
+	}{
+		{
+			name:    "stands on soft 17",
+			cards:   []deck.Card{{Rank: deck.Four}, {Rank: deck.Six}, {Rank: deck.Ace}},
+			wantSum: 17,
+		},
+		{
+			name:      "hits soft 17",
+			cards:     []deck.Card{{Rank: deck.Four}, {Rank: deck.Six}, {Rank: deck.Ace}},
+			hitSoft17: true,
+			wantSum:   21,
+		},
+		{
+			name:      "stands on hard 17",
+			cards:     []deck.Card{{Rank: deck.Four}, {Rank: deck.Seven}, {Rank: deck.King}},
+			hitSoft17: true,
+			wantSum:   17,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDealer()
+			d.play(tt.cards, tt.hitSoft17)
+
+			if got := d.hand.sum(); got != tt.wantSum {
+				t.Errorf("want %d, got %d", tt.wantSum, got)
+			}
+		})
+	}
+}
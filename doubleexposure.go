@@ -0,0 +1,19 @@
+package blackjack
+
+// DoubleExposureRules returns the rules of Double Exposure. Both cards of the dealer are dealt face up,
+// black jack pays even money and the dealer wins every tie except for a tied black jack.
+// Doubling is only allowed on 9, 10 and 11 and not after a split.
+func DoubleExposureRules() Rules {
+	return Rules{
+		Decks:           6,
+		DealerExposed:   true,
+		DealerWinsTies:  true,
+		BlackJackPayout: EvenMoney,
+		DoubleOn:        DoubleNineToEleven,
+	}
+}
+
+// NewDoubleExposure creates a pointer to a Table playing DoubleExposureRules. Options are applied on top of the variant.
+func NewDoubleExposure(opts ...func(t *Table) *Table) *Table {
+	return New(append([]func(t *Table) *Table{WithRules(DoubleExposureRules())}, opts...)...)
+}
@@ -0,0 +1,129 @@
+package blackjack
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/Hydoc/deck"
+)
+
+func TestNewDoubleExposure(t *testing.T) {
+	table := NewDoubleExposure(WithBetLimits(5*Unit, 0))
+
+	if !table.rules.DealerExposed || !table.rules.DealerWinsTies || table.rules.MinBet != 5*Unit {
+		t.Errorf("want Double Exposure rules with limits, got %#v", table.rules)
+	}
+}
+
+func TestTable_StateDealerCards(t *testing.T) {
+	cards := stack(
+		deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+		deck.Card{Rank: deck.Five, Suit: deck.Club},
+		deck.Card{Rank: deck.Nine, Suit: deck.Heart},
+		deck.Card{Rank: deck.Ten, Suit: deck.Spade},
+		deck.Card{Rank: deck.Two, Suit: deck.Spade},
+	)
+
+	tests := []struct {
+		name      string
+		table     *Table
+		play      bool
+		wantCards []deck.Card
+	}{
+		{
+			name:      "only the up card while playing",
+			table:     New(),
+			wantCards: []deck.Card{{Rank: deck.Five, Suit: deck.Club}},
+		},
+		{
+			name:      "both cards while playing double exposure",
+			table:     NewDoubleExposure(),
+			wantCards: []deck.Card{{Rank: deck.Five, Suit: deck.Club}, {Rank: deck.Ten, Suit: deck.Spade}},
+		},
+		{
+			name:  "every card after the round",
+			table: New(),
+			play:  true,
+			wantCards: []deck.Card{
+				{Rank: deck.Five, Suit: deck.Club},
+				{Rank: deck.Ten, Suit: deck.Spade},
+				{Rank: deck.Two, Suit: deck.Spade},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.table.deck = slices.Clone(cards)
+			player := NewPlayer(100 * Unit)
+			tt.table.Join(player)
+			tt.table.Bet(player, 10*Unit)
+			tt.table.Start()
+			if tt.play {
+				tt.table.Stand()
+			}
+
+			if got := tt.table.State().DealerCards; !reflect.DeepEqual(got, tt.wantCards) {
+				t.Errorf("want %#v, got %#v", tt.wantCards, got)
+			}
+		})
+	}
+}
+
+func TestDoubleExposure_Settle(t *testing.T) {
+	tests := []struct {
+		name        string
+		deck        []deck.Card
+		wantBalance Money
+	}{
+		{
+			name: "tie loses",
+			deck: stack(
+				deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+				deck.Card{Rank: deck.Ten, Suit: deck.Club},
+				deck.Card{Rank: deck.Eight, Suit: deck.Heart},
+				deck.Card{Rank: deck.Eight, Suit: deck.Spade},
+			),
+			wantBalance: 90 * Unit,
+		},
+		{
+			name: "black jack pays even money",
+			deck: stack(
+				deck.Card{Rank: deck.Ace, Suit: deck.Heart},
+				deck.Card{Rank: deck.Ten, Suit: deck.Club},
+				deck.Card{Rank: deck.King, Suit: deck.Heart},
+				deck.Card{Rank: deck.Eight, Suit: deck.Spade},
+			),
+			wantBalance: 110 * Unit,
+		},
+		{
+			name: "tied black jack wins",
+			deck: stack(
+				deck.Card{Rank: deck.Ace, Suit: deck.Heart},
+				deck.Card{Rank: deck.Ace, Suit: deck.Club},
+				deck.Card{Rank: deck.King, Suit: deck.Heart},
+				deck.Card{Rank: deck.Queen, Suit: deck.Spade},
+			),
+			wantBalance: 110 * Unit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewDoubleExposure()
+			table.deck = tt.deck
+			player := NewPlayer(100 * Unit)
+			table.Join(player)
+			table.Bet(player, 10*Unit)
+			table.Start()
+			if table.InProgress() {
+				table.Stand()
+			}
+
+			if got := player.Balance(); got != tt.wantBalance {
+				t.Errorf("want %s, got %s", tt.wantBalance, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package ev
+
+import (
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/deck"
+)
+
+// Coach advises with exact expected values. It implements blackjack.Coach, the shoe is the full shoe of the rules
+// without the player's cards and the up card, since a coach does not see the other cards played.
+type Coach struct {
+	Rules blackjack.Rules
+}
+
+// Advise returns the best of the legal actions and the expected value of every legal action.
+func (c Coach) Advise(cards []deck.Card, up deck.Card, legal []blackjack.Action) (blackjack.Action, map[blackjack.Action]float64) {
+	shoe := NewShoe(c.Rules).Remove(cards...).Remove(up)
+	all, err := Compute(c.Rules, shoe, cards, up)
+	if err != nil {
+		return blackjack.ActionStand, nil
+	}
+
+	best, bestValue := blackjack.ActionStand, 0.0
+	values := make(map[blackjack.Action]float64, len(legal))
+	for _, a := range legal {
+		v, ok := all[a]
+		if !ok {
+			continue
+		}
+		if len(values) == 0 || v > bestValue {
+			best, bestValue = a, v
+		}
+		values[a] = v
+	}
+	return best, values
+}
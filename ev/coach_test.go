@@ -0,0 +1,59 @@
+package ev
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/deck"
+)
+
+func TestCoach_Advise(t *testing.T) {
+	var coach blackjack.Coach = Coach{Rules: blackjack.Rules{Decks: 6, DoubleOn: blackjack.DoubleAnyTwo, Peek: true}}
+	up := deck.Card{Rank: deck.Six}
+
+	tests := []struct {
+		name  string
+		cards []deck.Card
+		legal []blackjack.Action
+		want  blackjack.Action
+	}{
+		{
+			name:  "doubles 11",
+			cards: cards(deck.Six, deck.Five),
+			legal: []blackjack.Action{blackjack.ActionHit, blackjack.ActionStand, blackjack.ActionDoubleDown},
+			want:  blackjack.ActionDoubleDown,
+		},
+		{
+			name:  "hits 11 when doubling is not legal",
+			cards: cards(deck.Six, deck.Five),
+			legal: []blackjack.Action{blackjack.ActionHit, blackjack.ActionStand},
+			want:  blackjack.ActionHit,
+		},
+		{
+			name:  "stands on 16",
+			cards: cards(deck.Ten, deck.Six),
+			legal: []blackjack.Action{blackjack.ActionHit, blackjack.ActionStand},
+			want:  blackjack.ActionStand,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, values := coach.Advise(test.cards, up, test.legal)
+			if got != test.want {
+				t.Errorf("want %#v, got %#v", test.want, got)
+			}
+
+			var actions []blackjack.Action
+			for _, a := range test.legal {
+				if _, ok := values[a]; ok {
+					actions = append(actions, a)
+				}
+			}
+			if !reflect.DeepEqual(actions, test.legal) {
+				t.Errorf("want values of %#v, got %#v", test.legal, values)
+			}
+		})
+	}
+}
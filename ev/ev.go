@@ -0,0 +1,277 @@
+// Package ev computes the exact expected values of the decisions of a blackjack hand for the composition of the
+// shoe which is left. Every card the player and the dealer draw is removed from the shoe, so the values reflect
+// exactly which cards have been played. For a table the shoe is NewShoe of its rules without every card seen
+// since the last shuffle, for example the State's dealer cards and the players' cards of every round.
+//
+// Two simplifications are made which every common calculator makes as well. A split is valued as twice the first
+// of the split hands, and when the dealer peeks the hole card is drawn after the player's cards.
+package ev
+
+import (
+	"errors"
+
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/deck"
+)
+
+var (
+	ErrNoCards = errors.New("hand has no cards")
+	ErrBusted  = errors.New("hand is busted")
+)
+
+// Compute returns the expected value of every action the rules allow for the cards against the dealer's up card,
+// in units of the original bet. The shoe must not contain the player's cards and the up card anymore.
+// Split and surrender are only valued for the first two cards of a hand which was not split.
+func Compute(rules blackjack.Rules, shoe Shoe, cards []deck.Card, up deck.Card) (map[blackjack.Action]float64, error) {
+	if len(cards) == 0 {
+		return nil, ErrNoCards
+	}
+
+	score := blackjack.Evaluate(cards)
+	if score.Busted {
+		return nil, ErrBusted
+	}
+
+	c := newCalc(rules, Value(up))
+	hard, ace := 0, false
+	for _, card := range cards {
+		hard += Value(card)
+		ace = ace || Value(card) == 1
+	}
+
+	ev := map[blackjack.Action]float64{
+		blackjack.ActionStand: c.stand(shoe, score.Total),
+		blackjack.ActionHit:   c.hit(shoe, hard, ace, c.hits),
+	}
+	if len(cards) == 2 && canDouble(rules.DoubleOn, score.Total) || len(cards) > 2 && rules.DoubleOn == blackjack.DoubleAny {
+		ev[blackjack.ActionDoubleDown] = c.double(shoe, hard, ace)
+	}
+	if len(cards) == 2 && cards[0].Rank == cards[1].Rank {
+		ev[blackjack.ActionSplit] = c.split(shoe, Value(cards[0]))
+	}
+	if len(cards) == 2 && rules.Surrender {
+		ev[blackjack.ActionSurrender] = c.surrender(shoe)
+	}
+	return ev, nil
+}
+
+// outcome holds the probabilities of the dealer's final hands. Index 0 to 5 hold the totals 17 to 22.
+type outcome struct {
+	total     [6]float64
+	bust      float64
+	blackJack float64
+}
+
+func (o *outcome) add(other *outcome, p float64) {
+	for i := range o.total {
+		o.total[i] += p * other.total[i]
+	}
+	o.bust += p * other.bust
+	o.blackJack += p * other.blackJack
+}
+
+// drawn packs the counts of the cards drawn by their value into five bits each.
+type drawn uint64
+
+func (d drawn) with(v int) drawn {
+	return d + 1<<(5*(v-1))
+}
+
+type calc struct {
+	rules blackjack.Rules
+	up    int
+
+	dealers map[Shoe]outcome
+	// memo caches the dealer's hands by the cards drawn while one shoe is played out.
+	memo map[drawn]outcome
+	// hits caches the expected values of hitting by the shoe left, for the hand Compute was called with.
+	hits map[Shoe]float64
+}
+
+func newCalc(rules blackjack.Rules, up int) *calc {
+	return &calc{
+		rules:   rules,
+		up:      up,
+		dealers: make(map[Shoe]outcome),
+		memo:    make(map[drawn]outcome),
+		hits:    make(map[Shoe]float64),
+	}
+}
+
+// dealer returns the outcomes of the dealer drawing from the shoe.
+func (c *calc) dealer(s Shoe) outcome {
+	if o, ok := c.dealers[s]; ok {
+		return o
+	}
+
+	clear(c.memo)
+	o := c.dealerDraw(&s, s.Size(), c.up, c.up == 1, 1, 0)
+	c.dealers[s] = o
+	return o
+}
+
+// dealerDraw plays the dealer's hand from the shoe which is left. The drawn cards identify the hand in the memo.
+func (c *calc) dealerDraw(s *Shoe, n, hard int, ace bool, cards int, d drawn) outcome {
+	if o, ok := c.memo[d]; ok {
+		return o
+	}
+
+	total, soft := hard, false
+	if ace && hard+10 <= 21 {
+		total, soft = hard+10, true
+	}
+
+	var o outcome
+	switch {
+	case cards == 2 && total == 21:
+		o.blackJack = 1
+	case total > 22:
+		o.bust = 1
+	case total >= 18 || total == 17 && !(soft && c.rules.DealerHitsSoft17), n == 0:
+		o.total[min(max(total, 17), 22)-17] = 1
+	default:
+		// a peeking dealer does not have a black jack, the hole card can not complete it
+		excluded := 0
+		if cards == 1 && c.rules.Peek && (c.up == 1 || c.up == 10) {
+			excluded = 11 - c.up
+		}
+		left := n - s[excluded]
+		for v := 1; v <= 10; v++ {
+			if s[v] == 0 || v == excluded {
+				continue
+			}
+			p := float64(s[v]) / float64(left)
+			s[v]--
+			next := c.dealerDraw(s, n-1, hard+v, ace || v == 1, cards+1, d.with(v))
+			s[v]++
+			o.add(&next, p)
+		}
+	}
+
+	c.memo[d] = o
+	return o
+}
+
+// stand returns the expected value of standing on the total with the shoe which is left.
+func (c *calc) stand(s Shoe, total int) float64 {
+	d := c.dealer(s)
+
+	tie := 0.0
+	if c.rules.DealerWinsTies {
+		tie = -1
+	}
+
+	ev := d.bust - d.blackJack
+	if !c.rules.DealerPushesOn22 {
+		ev += d.total[22-17]
+	}
+	for t := 17; t <= 21; t++ {
+		switch {
+		case total > t:
+			ev += d.total[t-17]
+		case total == t:
+			ev += tie * d.total[t-17]
+		default:
+			ev -= d.total[t-17]
+		}
+	}
+	return ev
+}
+
+// hit returns the expected value of hitting the hand and playing on with the best of hitting and standing.
+// The cache has to belong to the hand, the shoe which is left identifies the cards drawn to it.
+func (c *calc) hit(s Shoe, hard int, ace bool, cache map[Shoe]float64) float64 {
+	if ev, ok := cache[s]; ok {
+		return ev
+	}
+
+	ev := 0.0
+	n := float64(s.Size())
+	for v := 1; v <= 10; v++ {
+		if s[v] == 0 {
+			continue
+		}
+		p := float64(s[v]) / n
+		next, withAce := hard+v, ace || v == 1
+		if next > 21 {
+			ev -= p
+			continue
+		}
+		rest := s.without(v)
+		ev += p * max(c.stand(rest, total(next, withAce)), c.hit(rest, next, withAce, cache))
+	}
+
+	cache[s] = ev
+	return ev
+}
+
+// double returns the expected value of doubling, in units of the original bet.
+func (c *calc) double(s Shoe, hard int, ace bool) float64 {
+	ev := 0.0
+	n := float64(s.Size())
+	for v := 1; v <= 10; v++ {
+		if s[v] == 0 {
+			continue
+		}
+		p := float64(s[v]) / n
+		if hard+v > 21 {
+			ev -= p
+			continue
+		}
+		ev += p * c.stand(s.without(v), total(hard+v, ace || v == 1))
+	}
+	return 2 * ev
+}
+
+// split returns the expected value of splitting the pair, in units of the original bet. Each split hand draws its
+// second card and is played on with hitting, standing and, if allowed after a split, doubling.
+func (c *calc) split(s Shoe, v int) float64 {
+	cache := make(map[Shoe]float64)
+
+	ev := 0.0
+	n := float64(s.Size())
+	for w := 1; w <= 10; w++ {
+		if s[w] == 0 {
+			continue
+		}
+		p := float64(s[w]) / n
+		rest := s.without(w)
+		hard, ace := v+w, v == 1 || w == 1
+
+		best := max(c.stand(rest, total(hard, ace)), c.hit(rest, hard, ace, cache))
+		if c.rules.DoubleAfterSplit && canDouble(c.rules.DoubleOn, total(hard, ace)) {
+			best = max(best, c.double(rest, hard, ace))
+		}
+		ev += p * best
+	}
+	return 2 * ev
+}
+
+// surrender returns the expected value of a late surrender. Without peeking a dealer black jack takes the whole bet.
+func (c *calc) surrender(s Shoe) float64 {
+	if c.rules.Peek || c.up != 1 && c.up != 10 {
+		return -0.5
+	}
+
+	blackJack := float64(s[11-c.up]) / float64(s.Size())
+	return -blackJack - 0.5*(1-blackJack)
+}
+
+// total returns the total of the hard total counting one ace as 11 if it does not bust.
+func total(hard int, ace bool) int {
+	if ace && hard+10 <= 21 {
+		return hard + 10
+	}
+	return hard
+}
+
+func canDouble(rule blackjack.DoubleRule, total int) bool {
+	switch rule {
+	case blackjack.DoubleTenToEleven:
+		return total == 10 || total == 11
+	case blackjack.DoubleAnyTwo, blackjack.DoubleAny:
+		return true
+	default:
+		return total >= 9 && total <= 11
+	}
+}
@@ -0,0 +1,194 @@
+package ev
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/deck"
+)
+
+func cards(ranks ...deck.Rank) []deck.Card {
+	c := make([]deck.Card, len(ranks))
+	for i, r := range ranks {
+		c[i] = deck.Card{Rank: r}
+	}
+	return c
+}
+
+func TestCompute(t *testing.T) {
+	sixDecks := blackjack.Rules{
+		Decks:            6,
+		DoubleOn:         blackjack.DoubleAnyTwo,
+		DoubleAfterSplit: true,
+		Surrender:        true,
+		Peek:             true,
+	}
+	noPeek := sixDecks
+	noPeek.Peek = false
+
+	tests := []struct {
+		name  string
+		rules blackjack.Rules
+		shoe  *Shoe
+		cards []deck.Card
+		up    deck.Rank
+		want  map[blackjack.Action]float64
+	}{
+		{
+			name:  "hard 16 against ten",
+			rules: sixDecks,
+			cards: cards(deck.Ten, deck.Six),
+			up:    deck.Ten,
+			want: map[blackjack.Action]float64{
+				blackjack.ActionStand:      -0.541,
+				blackjack.ActionHit:        -0.535,
+				blackjack.ActionDoubleDown: -1.069,
+				blackjack.ActionSurrender:  -0.5,
+			},
+		},
+		{
+			name:  "hard 11 against ace",
+			rules: sixDecks,
+			cards: cards(deck.Six, deck.Five),
+			up:    deck.Ace,
+			want: map[blackjack.Action]float64{
+				blackjack.ActionStand:      -0.662,
+				blackjack.ActionHit:        0.147,
+				blackjack.ActionDoubleDown: 0.127,
+				blackjack.ActionSurrender:  -0.5,
+			},
+		},
+		{
+			name:  "eights against ten",
+			rules: sixDecks,
+			cards: cards(deck.Eight, deck.Eight),
+			up:    deck.Ten,
+			want: map[blackjack.Action]float64{
+				blackjack.ActionStand:      -0.537,
+				blackjack.ActionHit:        -0.535,
+				blackjack.ActionDoubleDown: -1.071,
+				blackjack.ActionSplit:      -0.483,
+				blackjack.ActionSurrender:  -0.5,
+			},
+		},
+		{
+			name:  "surrender without peek loses the whole bet against a black jack",
+			rules: noPeek,
+			shoe:  &Shoe{0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 3},
+			cards: cards(deck.Ten, deck.Six),
+			up:    deck.Ten,
+			want: map[blackjack.Action]float64{
+				blackjack.ActionStand:      -1,
+				blackjack.ActionHit:        -1,
+				blackjack.ActionDoubleDown: -2,
+				blackjack.ActionSurrender:  -0.625,
+			},
+		},
+		{
+			name:  "only tens left",
+			rules: sixDecks,
+			shoe:  &Shoe{10: 20},
+			cards: cards(deck.Ten, deck.Two),
+			up:    deck.Ten,
+			want: map[blackjack.Action]float64{
+				blackjack.ActionStand:      -1,
+				blackjack.ActionHit:        -1,
+				blackjack.ActionDoubleDown: -2,
+				blackjack.ActionSurrender:  -0.5,
+			},
+		},
+		{
+			name:  "only fives left",
+			rules: sixDecks,
+			shoe:  &Shoe{5: 20},
+			cards: cards(deck.Ten, deck.Six),
+			up:    deck.Ten,
+			want: map[blackjack.Action]float64{
+				blackjack.ActionStand:      -1,
+				blackjack.ActionHit:        1,
+				blackjack.ActionDoubleDown: 2,
+				blackjack.ActionSurrender:  -0.5,
+			},
+		},
+		{
+			name:  "three cards can not split or surrender",
+			rules: blackjack.Rules{Decks: 1},
+			shoe:  &Shoe{5: 20},
+			cards: cards(deck.Two, deck.Two, deck.Two),
+			up:    deck.Ten,
+			want: map[blackjack.Action]float64{
+				blackjack.ActionStand: -1,
+				blackjack.ActionHit:   1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			up := deck.Card{Rank: test.up}
+			shoe := NewShoe(test.rules).Remove(test.cards...).Remove(up)
+			if test.shoe != nil {
+				shoe = *test.shoe
+			}
+
+			got, err := Compute(test.rules, shoe, test.cards, up)
+			if err != nil {
+				t.Fatalf("want no error, got %v", err)
+			}
+			if len(got) != len(test.want) {
+				t.Fatalf("want %#v, got %#v", test.want, got)
+			}
+			for action, want := range test.want {
+				if math.Abs(got[action]-want) > 0.001 {
+					t.Errorf("%s: want %#v, got %#v", action, want, got[action])
+				}
+			}
+		})
+	}
+}
+
+func TestCompute_Composition(t *testing.T) {
+	rules := blackjack.Rules{Decks: 1, Peek: true}
+	hand, up := cards(deck.Ten, deck.Six), deck.Card{Rank: deck.Ten}
+	full := NewShoe(rules).Remove(hand...).Remove(up)
+
+	fewTens := full
+	fewTens[10] -= 8
+	got, err := Compute(rules, fewTens, hand, up)
+	if err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+	fresh, err := Compute(rules, full, hand, up)
+	if err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+
+	if got[blackjack.ActionHit] <= fresh[blackjack.ActionHit] {
+		t.Errorf("want hitting with fewer tens left to be better than %#v, got %#v", fresh[blackjack.ActionHit], got[blackjack.ActionHit])
+	}
+}
+
+func TestCompute_Errors(t *testing.T) {
+	rules := blackjack.DefaultRules()
+	up := deck.Card{Rank: deck.Ten}
+
+	tests := []struct {
+		name  string
+		cards []deck.Card
+		want  error
+	}{
+		{name: "no cards", want: ErrNoCards},
+		{name: "busted", cards: cards(deck.Ten, deck.Six, deck.Nine), want: ErrBusted},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Compute(rules, NewShoe(rules), test.cards, up)
+			if !errors.Is(err, test.want) {
+				t.Errorf("want %v, got %v", test.want, err)
+			}
+		})
+	}
+}
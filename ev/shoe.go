@@ -0,0 +1,69 @@
+package ev
+
+import (
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/deck"
+)
+
+// Shoe is the composition of the cards left to draw, counted by value. Index 1 holds the aces and index 10 every
+// ten-valued card, index 0 is unused.
+type Shoe [11]int
+
+// NewShoe returns the full shoe of the rules.
+func NewShoe(rules blackjack.Rules) Shoe {
+	decks := rules.Decks
+	if decks == 0 {
+		decks = 6
+	}
+
+	var s Shoe
+	for v := 1; v <= 9; v++ {
+		s[v] = 4 * decks
+	}
+	s[10] = 16 * decks
+	if rules.StripTens {
+		s[10] = 12 * decks
+	}
+	return s
+}
+
+// ShoeOf counts the cards into a shoe.
+func ShoeOf(cards []deck.Card) Shoe {
+	var s Shoe
+	for _, c := range cards {
+		s[Value(c)]++
+	}
+	return s
+}
+
+// Remove returns the shoe without the cards, for example the ones already dealt. Cards which are not left are ignored.
+func (s Shoe) Remove(cards ...deck.Card) Shoe {
+	for _, c := range cards {
+		if v := Value(c); s[v] > 0 {
+			s[v]--
+		}
+	}
+	return s
+}
+
+// Size returns the amount of cards left.
+func (s Shoe) Size() int {
+	n := 0
+	for _, c := range s {
+		n += c
+	}
+	return n
+}
+
+func (s Shoe) without(v int) Shoe {
+	s[v]--
+	return s
+}
+
+// Value returns the index of the card in a Shoe, 1 for the ace and 10 for every ten-valued card.
+func Value(card deck.Card) int {
+	if card.Rank >= deck.Ten {
+		return 10
+	}
+	return int(card.Rank)
+}
@@ -0,0 +1,67 @@
+package ev
+
+import (
+	"testing"
+
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/deck"
+)
+
+func TestNewShoe(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules blackjack.Rules
+		want  Shoe
+	}{
+		{
+			name:  "six decks by default",
+			rules: blackjack.Rules{},
+			want:  Shoe{0, 24, 24, 24, 24, 24, 24, 24, 24, 24, 96},
+		},
+		{
+			name:  "one deck",
+			rules: blackjack.Rules{Decks: 1},
+			want:  Shoe{0, 4, 4, 4, 4, 4, 4, 4, 4, 4, 16},
+		},
+		{
+			name:  "stripped tens",
+			rules: blackjack.Rules{Decks: 2, StripTens: true},
+			want:  Shoe{0, 8, 8, 8, 8, 8, 8, 8, 8, 8, 24},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := NewShoe(test.rules)
+			if got != test.want {
+				t.Errorf("want %#v, got %#v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestShoe_Remove(t *testing.T) {
+	s := Shoe{0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 2}.Remove(
+		deck.Card{Rank: deck.Ace},
+		deck.Card{Rank: deck.King},
+		deck.Card{Rank: deck.Ace},
+		deck.Card{Rank: deck.Two},
+	)
+
+	want := Shoe{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}
+	if s != want {
+		t.Errorf("want %#v, got %#v", want, s)
+	}
+	if s.Size() != 1 {
+		t.Errorf("want %#v, got %#v", 1, s.Size())
+	}
+}
+
+func TestShoeOf(t *testing.T) {
+	got := ShoeOf([]deck.Card{{Rank: deck.Ace}, {Rank: deck.Jack}, {Rank: deck.Ten}, {Rank: deck.Five}})
+
+	want := Shoe{0, 1, 0, 0, 0, 1, 0, 0, 0, 0, 2}
+	if got != want {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+}
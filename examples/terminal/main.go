@@ -1,60 +1,369 @@
+// Command terminal is a game of blackjack in the terminal for up to seven players taking turns at the keyboard.
+// Every round the players place their bets and play their hands, until everyone left the table or went broke.
 package main
 
 import (
+	"bufio"
+	"errors"
+	"flag"
 	"fmt"
-	"log/slog"
+	"io"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/Hydoc/deck"
 
 	"github.com/Hydoc/blackjack"
 )
 
-var cardTemplate = ` _______
-|%s		|
-|		|
-|	%s	|
-|		|
-|_______|`
+const maxPlayers = 7
+
+// keys are the keys to type for the actions and how they are offered.
+var keys = map[blackjack.Action]struct{ key, label string }{
+	blackjack.ActionHit:        {"h", "[h]it"},
+	blackjack.ActionStand:      {"s", "[s]tand"},
+	blackjack.ActionDoubleDown: {"d", "[d]ouble"},
+	blackjack.ActionSplit:      {"p", "s[p]lit"},
+	blackjack.ActionSurrender:  {"r", "su[r]render"},
+}
+
+var suits = map[deck.Suit]string{
+	deck.Spade:   "♠",
+	deck.Club:    "♣",
+	deck.Diamond: "♦",
+	deck.Heart:   "♥",
+}
 
 func main() {
-	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
-	table := blackjack.New()
-	playerOne := blackjack.NewPlayer(500, blackjack.WithName("One"))
-	playerTwo := blackjack.NewPlayer(500, blackjack.WithName("Two"))
-
-	err := table.Join(playerOne)
-	if err != nil {
-		logger.Error(err.Error())
-		os.Exit(1)
+	balance := flag.Int64("balance", 500, "balance every player starts with")
+	bet := flag.Int64("bet", 10, "bet suggested every round")
+	decks := flag.Int("decks", 6, "decks in the shoe")
+	surrender := flag.Bool("surrender", true, "late surrender")
+	flag.Parse()
+
+	rules := blackjack.DefaultRules()
+	rules.Decks = *decks
+	rules.Surrender = *surrender
+
+	g := &game{
+		table:   blackjack.New(blackjack.WithRules(rules), blackjack.WithBetLimits(blackjack.Unit, 0)),
+		in:      bufio.NewScanner(os.Stdin),
+		out:     os.Stdout,
+		balance: blackjack.Money(*balance) * blackjack.Unit,
+		bet:     blackjack.Money(*bet) * blackjack.Unit,
 	}
-	err = table.Join(playerTwo)
-	if err != nil {
-		logger.Error(err.Error())
+	if err := g.run(); err != nil && !errors.Is(err, io.EOF) {
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
+}
+
+type game struct {
+	table   *blackjack.Table
+	in      *bufio.Scanner
+	out     io.Writer
+	balance blackjack.Money
+	bet     blackjack.Money
+	players []*blackjack.Player
+}
+
+// run seats the players and plays rounds until nobody is left at the table. It returns io.EOF when the input ends.
+func (g *game) run() error {
+	if err := g.seat(); err != nil {
+		return err
+	}
+
+	for len(g.players) > 0 {
+		before, err := g.bets()
+		if err != nil {
+			return err
+		}
+		if len(g.players) == 0 {
+			break
+		}
+
+		if err := g.table.Start(); err != nil {
+			fmt.Fprintln(g.out, err)
+		}
+		for g.table.InProgress() {
+			if err := g.turn(); err != nil {
+				return err
+			}
+		}
+
+		fmt.Fprintln(g.out)
+		fmt.Fprintln(g.out, "The dealer reveals")
+		g.render()
+		g.settle(before)
+	}
+
+	fmt.Fprintln(g.out, "Nobody is left at the table, bye.")
+	return nil
+}
+
+// seat asks for the names of the players until an empty name is entered or the table is full.
+func (g *game) seat() error {
+	for len(g.players) < maxPlayers {
+		name, err := g.ask(fmt.Sprintf("Name of player %d (empty to start): ", len(g.players)+1))
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			break
+		}
+
+		p := blackjack.NewPlayer(g.balance, blackjack.WithName(name))
+		if err := g.table.Join(p); err != nil {
+			return err
+		}
+		g.players = append(g.players, p)
+	}
+
+	if len(g.players) == 0 {
+		p := blackjack.NewPlayer(g.balance, blackjack.WithName("Player"))
+		if err := g.table.Join(p); err != nil {
+			return err
+		}
+		g.players = append(g.players, p)
+	}
+	return nil
+}
+
+// bets asks every player for the bet of the round, players answering q leave the table.
+// It returns the balances before betting to tell the players what they won.
+func (g *game) bets() (map[*blackjack.Player]blackjack.Money, error) {
+	before := make(map[*blackjack.Player]blackjack.Money, len(g.players))
+
+	fmt.Fprintln(g.out)
+	for _, p := range slices.Clone(g.players) {
+		for {
+			answer, err := g.ask(fmt.Sprintf("%s, balance %s, bet [%s] or q to leave: ", p.Name, p.Balance(), g.bet))
+			if err != nil {
+				return nil, err
+			}
+			if strings.EqualFold(answer, "q") {
+				g.leave(p)
+				break
+			}
+
+			amount := g.bet
+			if answer != "" {
+				if amount, err = blackjack.ParseMoney(answer); err != nil {
+					fmt.Fprintln(g.out, err)
+					continue
+				}
+			}
+
+			balance := p.Balance()
+			if err := g.table.Bet(p, amount); err != nil {
+				fmt.Fprintln(g.out, err)
+				continue
+			}
+			before[p] = balance
+			break
+		}
+	}
+	return before, nil
+}
+
+// turn shows the table and lets the turn player take one of the legal actions.
+func (g *game) turn() error {
+	g.render()
+
+	state := g.table.State()
+	legal := g.table.LegalActions()
+	options := make([]string, 0, len(legal))
+	for _, a := range legal {
+		options = append(options, keys[a].label)
+	}
 
-	table.Start()
+	for {
+		answer, err := g.ask(fmt.Sprintf("%s: %s? ", state.TurnPlayer.Name, strings.Join(options, " ")))
+		if err != nil {
+			return err
+		}
+
+		action, ok := parseAction(answer, legal)
+		if !ok {
+			fmt.Fprintf(g.out, "%q is not one of the options\n", answer)
+			continue
+		}
+		if err := g.apply(action); err != nil {
+			fmt.Fprintln(g.out, err)
+		}
+		return nil
+	}
+}
+
+func (g *game) apply(action blackjack.Action) error {
+	switch action {
+	case blackjack.ActionHit:
+		return g.table.Hit()
+	case blackjack.ActionDoubleDown:
+		return g.table.DoubleDown()
+	case blackjack.ActionSplit:
+		return g.table.Split()
+	case blackjack.ActionSurrender:
+		return g.table.Surrender()
+	default:
+		return g.table.Stand()
+	}
+}
+
+// settle tells every player what the round won or lost, players who can not afford another bet leave the table.
+func (g *game) settle(before map[*blackjack.Player]blackjack.Money) {
+	fmt.Fprintln(g.out)
+	for _, p := range slices.Clone(g.players) {
+		switch delta := p.Balance() - before[p]; {
+		case delta > 0:
+			fmt.Fprintf(g.out, "%s won %s\n", p.Name, delta)
+		case delta < 0:
+			fmt.Fprintf(g.out, "%s lost %s\n", p.Name, -delta)
+		default:
+			fmt.Fprintf(g.out, "%s pushed\n", p.Name)
+		}
+
+		if p.Balance() < blackjack.Unit {
+			fmt.Fprintf(g.out, "%s is broke and leaves the table\n", p.Name)
+			g.leave(p)
+		}
+	}
+}
+
+func (g *game) leave(p *blackjack.Player) {
+	g.table.Leave(p)
+	for i := range g.players {
+		if g.players[i] == p {
+			g.players = append(g.players[:i], g.players[i+1:]...)
+			return
+		}
+	}
+}
+
+// render prints the dealer's cards, face down ones included, and every hand of the players with its total.
+// The hand which is played is marked.
+func (g *game) render() {
+	state := g.table.State()
+	hidden := len(state.Dealer.Cards()) - len(state.DealerCards)
+
+	fmt.Fprintln(g.out)
+	fmt.Fprintf(g.out, "Dealer (%s)\n", total(state.DealerCards))
+	printCards(g.out, state.DealerCards, hidden)
 
-	for !table.IsDone() {
-		state := table.State()
+	for _, p := range g.players {
+		hands := p.Hands()
+		for i, cards := range hands {
+			marker := "  "
+			if p == state.TurnPlayer && i == p.ActiveHand() {
+				marker = "> "
+			}
+			name := p.Name
+			if len(hands) > 1 {
+				name = fmt.Sprintf("%s, hand %d", p.Name, i+1)
+			}
 
-		printDealer(state.Dealer, true)
+			fmt.Fprintf(g.out, "%s%s (%s), balance %s\n", marker, name, total(cards), p.Balance())
+			printCards(g.out, cards, 0)
+		}
+	}
+}
 
-		for _, p := range state.Players {
+// ask prints the prompt and reads a line. It returns io.EOF when there is no more input.
+func (g *game) ask(prompt string) (string, error) {
+	fmt.Fprint(g.out, prompt)
+	if !g.in.Scan() {
+		fmt.Fprintln(g.out)
+		if err := g.in.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return strings.TrimSpace(g.in.Text()), nil
+}
 
-			fmt.Println(p.Name)
+// parseAction returns the legal action for the key typed.
+func parseAction(answer string, legal []blackjack.Action) (blackjack.Action, bool) {
+	answer = strings.ToLower(answer)
+	for _, a := range legal {
+		if answer == keys[a].key {
+			return a, true
 		}
 	}
+	return 0, false
 }
 
-func printCard(card deck.Card) {
-	fmt.Printf(cardTemplate, card.Rank, card.Suit)
+// total describes the score of the cards, like soft 17.
+func total(cards []deck.Card) string {
+	score := blackjack.Evaluate(cards)
+	switch {
+	case len(cards) == 0:
+		return "-"
+	case score.BlackJack:
+		return "black jack"
+	case score.Busted:
+		return fmt.Sprintf("%d, busted", score.Total)
+	case score.Soft:
+		return fmt.Sprintf("soft %d", score.Total)
+	default:
+		return strconv.Itoa(score.Total)
+	}
+}
+
+// printCards prints the cards side by side, followed by the given number of face down cards.
+func printCards(w io.Writer, cards []deck.Card, hidden int) {
+	var lines [6]string
+	for _, c := range cards {
+		for i, line := range face(c) {
+			lines[i] += line + " "
+		}
+	}
+	for range hidden {
+		for i, line := range back() {
+			lines[i] += line + " "
+		}
+	}
+
+	for _, line := range lines {
+		fmt.Fprintln(w, strings.TrimRight(line, " "))
+	}
+}
+
+func face(c deck.Card) [6]string {
+	rank := label(c.Rank)
+	return [6]string{
+		" _______ ",
+		"|" + rank + strings.Repeat(" ", 7-len(rank)) + "|",
+		"|       |",
+		"|   " + suits[c.Suit] + "   |",
+		"|       |",
+		"|" + strings.Repeat("_", 7-len(rank)) + rank + "|",
+	}
+}
+
+func back() [6]string {
+	return [6]string{
+		" _______ ",
+		"|+-+-+-+|",
+		"|-+-+-+-|",
+		"|+-+-+-+|",
+		"|-+-+-+-|",
+		"|+-+-+-+|",
+	}
 }
 
-func printDealer(d *blackjack.Dealer, onlyFirstCard bool) {
-	if onlyFirstCard {
-		printCard(d.Cards()[0])
-		return
+func label(r deck.Rank) string {
+	switch r {
+	case deck.Ace:
+		return "A"
+	case deck.Jack:
+		return "J"
+	case deck.Queen:
+		return "Q"
+	case deck.King:
+		return "K"
+	default:
+		return strconv.Itoa(int(r))
 	}
 }
@@ -0,0 +1,20 @@
+package blackjack
+
+// FreeBetRules returns the rules of Free Bet Blackjack. The house funds doubles on a hard 9, 10 or 11 and splits
+// of every pair except for tens with a free bet, which is paid on a win but never lost.
+// A dealer 22 pushes against every hand which is not busted, except for a black jack.
+func FreeBetRules() Rules {
+	return Rules{
+		Decks:            6,
+		FreeDoubles:      true,
+		FreeSplits:       true,
+		DealerPushesOn22: true,
+		DoubleOn:         DoubleAnyTwo,
+		DoubleAfterSplit: true,
+	}
+}
+
+// NewFreeBet creates a pointer to a Table playing FreeBetRules. Options are applied on top of the variant.
+func NewFreeBet(opts ...func(t *Table) *Table) *Table {
+	return New(append([]func(t *Table) *Table{WithRules(FreeBetRules())}, opts...)...)
+}
@@ -0,0 +1,158 @@
+package blackjack
+
+import (
+	"testing"
+
+	"github.com/Hydoc/deck"
+)
+
+func TestNewFreeBet(t *testing.T) {
+	table := NewFreeBet(WithBetLimits(5*Unit, 0))
+
+	if !table.rules.FreeDoubles || !table.rules.FreeSplits || !table.rules.DealerPushesOn22 || table.rules.MinBet != 5*Unit {
+		t.Errorf("want Free Bet rules with limits, got %#v", table.rules)
+	}
+}
+
+func TestFreeBet_Play(t *testing.T) {
+	tests := []struct {
+		name            string
+		deck            []deck.Card
+		play            func(table *Table) error
+		wantBalance     Money
+		wantBalanceLive Money
+	}{
+		{
+			name: "free double wins",
+			deck: stack(
+				deck.Card{Rank: deck.Six, Suit: deck.Heart},
+				deck.Card{Rank: deck.King, Suit: deck.Club},
+				deck.Card{Rank: deck.Five, Suit: deck.Club},
+				deck.Card{Rank: deck.Seven, Suit: deck.Club},
+				deck.Card{Rank: deck.Nine, Suit: deck.Heart},
+			),
+			play:            func(table *Table) error { return table.DoubleDown() },
+			wantBalanceLive: 90 * Unit,
+			wantBalance:     120 * Unit,
+		},
+		{
+			name: "free double loses only the bet",
+			deck: stack(
+				deck.Card{Rank: deck.Six, Suit: deck.Heart},
+				deck.Card{Rank: deck.King, Suit: deck.Club},
+				deck.Card{Rank: deck.Five, Suit: deck.Club},
+				deck.Card{Rank: deck.Nine, Suit: deck.Club},
+				deck.Card{Rank: deck.Two, Suit: deck.Heart},
+			),
+			play:            func(table *Table) error { return table.DoubleDown() },
+			wantBalanceLive: 90 * Unit,
+			wantBalance:     90 * Unit,
+		},
+		{
+			name: "soft double is paid",
+			deck: stack(
+				deck.Card{Rank: deck.Ace, Suit: deck.Heart},
+				deck.Card{Rank: deck.King, Suit: deck.Club},
+				deck.Card{Rank: deck.Seven, Suit: deck.Club},
+				deck.Card{Rank: deck.Seven, Suit: deck.Diamond},
+				deck.Card{Rank: deck.Two, Suit: deck.Heart},
+			),
+			play:            func(table *Table) error { return table.DoubleDown() },
+			wantBalanceLive: 80 * Unit,
+			wantBalance:     120 * Unit,
+		},
+		{
+			name: "free split",
+			deck: stack(
+				deck.Card{Rank: deck.Eight, Suit: deck.Heart},
+				deck.Card{Rank: deck.King, Suit: deck.Club},
+				deck.Card{Rank: deck.Eight, Suit: deck.Club},
+				deck.Card{Rank: deck.Seven, Suit: deck.Diamond},
+				deck.Card{Rank: deck.Queen, Suit: deck.Heart},
+				deck.Card{Rank: deck.King, Suit: deck.Heart},
+			),
+			play: func(table *Table) error {
+				if err := table.Split(); err != nil {
+					return err
+				}
+				if err := table.Stand(); err != nil {
+					return err
+				}
+				return table.Stand()
+			},
+			wantBalanceLive: 90 * Unit,
+			wantBalance:     120 * Unit,
+		},
+		{
+			name: "tens are split for the bet",
+			deck: stack(
+				deck.Card{Rank: deck.King, Suit: deck.Heart},
+				deck.Card{Rank: deck.Seven, Suit: deck.Club},
+				deck.Card{Rank: deck.King, Suit: deck.Club},
+				deck.Card{Rank: deck.Ten, Suit: deck.Diamond},
+				deck.Card{Rank: deck.Queen, Suit: deck.Heart},
+				deck.Card{Rank: deck.Jack, Suit: deck.Heart},
+			),
+			play: func(table *Table) error {
+				if err := table.Split(); err != nil {
+					return err
+				}
+				if err := table.Stand(); err != nil {
+					return err
+				}
+				return table.Stand()
+			},
+			wantBalanceLive: 80 * Unit,
+			wantBalance:     120 * Unit,
+		},
+		{
+			name: "dealer 22 pushes",
+			deck: stack(
+				deck.Card{Rank: deck.King, Suit: deck.Heart},
+				deck.Card{Rank: deck.Six, Suit: deck.Club},
+				deck.Card{Rank: deck.Nine, Suit: deck.Club},
+				deck.Card{Rank: deck.Six, Suit: deck.Diamond},
+				deck.Card{Rank: deck.King, Suit: deck.Diamond},
+			),
+			play:            func(table *Table) error { return table.Stand() },
+			wantBalanceLive: 90 * Unit,
+			wantBalance:     100 * Unit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewFreeBet()
+			table.deck = tt.deck
+			player := NewPlayer(100 * Unit)
+			table.Join(player)
+			table.Bet(player, 10*Unit)
+
+			if err := table.Start(); err != nil {
+				t.Fatalf("want nil, got %v", err)
+			}
+
+			if err := tt.play(table); err != nil {
+				t.Fatalf("want nil, got %v", err)
+			}
+
+			if table.InProgress() {
+				t.Fatalf("want round to be done")
+			}
+
+			if got := player.Balance(); got != tt.wantBalance {
+				t.Errorf("want %s, got %s", tt.wantBalance, got)
+			}
+
+			var debited Money
+			for _, e := range table.Ledger().Entries() {
+				if e.Kind == Debit {
+					debited += e.Amount
+				}
+			}
+			if got := 100*Unit - debited; got != tt.wantBalanceLive {
+				t.Errorf("want %s after betting, got %s", tt.wantBalanceLive, got)
+			}
+		})
+	}
+}
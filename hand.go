@@ -11,11 +11,42 @@ var (
 	ErrNotAllowed = errors.New("not allowed")
 )
 
+// Outcome is the result of a hand against the dealer.
+type Outcome int
+
+const (
+	Lose Outcome = iota
+	Push
+	Win
+	BlackJackWin
+	Surrendered
+	FiveCardTrickWin
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case Push:
+		return "push"
+	case Win:
+		return "win"
+	case BlackJackWin:
+		return "black jack"
+	case Surrendered:
+		return "surrendered"
+	case FiveCardTrickWin:
+		return "five-card trick"
+	default:
+		return "lose"
+	}
+}
+
 type hands struct {
-	mode   Mode
-	first  *hand
-	second *hand
-	active *hand
+	mode     Mode
+	first    *hand
+	second   *hand
+	active   *hand
+	switched bool
+	sideBets []placedSideBet
 }
 
 func (h *hands) hasBlackJack() bool {
@@ -30,12 +61,16 @@ func (h *hands) doubleDown(card deck.Card) {
 	h.active.doubleDown(card)
 }
 
+func (h *hands) doubleDownFor(card deck.Card, amount Money) {
+	h.active.doubleDownFor(card, amount)
+}
+
 func (h *hands) stand() {
 	if h.first.isActive {
 		h.first.isActive = false
 		h.active = nil
 
-		if h.mode == split {
+		if h.mode != normal {
 			h.second.isActive = true
 			h.active = h.second
 		}
@@ -45,8 +80,37 @@ func (h *hands) stand() {
 	}
 }
 
+func (h *hands) doubleDownFree(card deck.Card) {
+	h.active.doubleDownFree(card)
+}
+
+func (h *hands) canSurrender() bool {
+	return h.mode == normal && len(h.active.cards) == 2
+}
+
+func (h *hands) surrender() {
+	h.active.surrendered = true
+	h.stand()
+}
+
+// canSwitch returns a bool whether the second cards of two hands can be swapped. This is only possible once,
+// before the first hand is played.
+func (h *hands) canSwitch() bool {
+	return h.mode == twoHands &&
+		!h.switched &&
+		h.active == h.first &&
+		len(h.first.cards) == 2 &&
+		len(h.second.cards) == 2 &&
+		h.first.doubles == 0
+}
+
+func (h *hands) switchCards() {
+	h.first.cards[1], h.second.cards[1] = h.second.cards[1], h.first.cards[1]
+	h.switched = true
+}
+
 func (h *hands) canSplit() bool {
-	return h.active.canSplit()
+	return h.mode == normal && h.active.canSplit()
 }
 
 func (h *hands) canDoubleDown() bool {
@@ -61,10 +125,48 @@ func (h *hands) isDone() bool {
 	return h.active == nil
 }
 
+// all returns the first hand and, after a split, the second hand.
+func (h *hands) all() []*hand {
+	if h.second == nil {
+		return []*hand{h.first}
+	}
+	return []*hand{h.first, h.second}
+}
+
+// dealt returns the first two cards dealt to the player, even after a split.
+func (h *hands) dealt() []deck.Card {
+	if h.mode == split {
+		return []deck.Card{h.first.cards[0], h.second.cards[0]}
+	}
+	return h.first.cards[:min(2, len(h.first.cards))]
+}
+
+// natural reports whether a hand of 21 with two cards counts as black jack, which it does not after a split
+// or a switch.
+func (h *hands) natural() bool {
+	return h.mode != split && !h.switched
+}
+
+// activeID returns 0 while the first hand is played and 1 for the second hand after a split.
+func (h *hands) activeID() int {
+	if h.active != nil && h.active == h.second {
+		return 1
+	}
+	return 0
+}
+
 type hand struct {
 	cards    []deck.Card
 	isActive bool
-	bet      int
+	bet      Money
+	// doubled is the amount the hand was doubled for, it is already part of bet.
+	doubled Money
+	// doubles is how often the hand was doubled.
+	doubles int
+	// freeBet is the stake funded by the house at Free Bet Blackjack. It is paid like the bet but never lost,
+	// and it is not part of bet.
+	freeBet     Money
+	surrendered bool
 }
 
 func (h *hand) hit(card deck.Card) {
@@ -72,12 +174,31 @@ func (h *hand) hit(card deck.Card) {
 }
 
 func (h *hand) doubleDown(card deck.Card) {
+	h.doubleDownFor(card, h.bet)
+}
+
+// doubleDownFor adds the amount to the bet, which may be less than the original bet, and hits the card.
+func (h *hand) doubleDownFor(card deck.Card, amount Money) {
+	h.hit(card)
+	h.bet += amount
+	h.doubled += amount
+	h.doubles++
+}
+
+// doubleDownFree doubles the stake with a free bet from the house and hits the card.
+func (h *hand) doubleDownFree(card deck.Card) {
 	h.hit(card)
-	h.bet += h.bet
+	h.freeBet += h.stake()
+	h.doubles++
+}
+
+// stake returns the whole wager on the hand, the bet of the player and the free bet of the house.
+func (h *hand) stake() Money {
+	return h.bet + h.freeBet
 }
 
 func (h *hand) hasBlackJack() bool {
-	return len(h.cards) == 2 && h.sum() == 21
+	return Evaluate(h.cards).BlackJack
 }
 
 func (h *hand) split() (*hands, error) {
@@ -93,35 +214,113 @@ func (h *hand) canSplit() bool {
 }
 
 func (h *hand) sum() int {
-	sum := 0
+	return Evaluate(h.cards).Total
+}
 
-	for _, card := range h.cards {
-		if card.Rank == deck.Jack || card.Rank == deck.Queen || card.Rank == deck.King {
-			sum += 10
-		} else {
-			sum += int(card.Rank)
-		}
+// outcome compares the hand with the dealer's score under the given rules.
+// A hand of 21 with two cards only counts as black jack if it is natural.
+func (h *hand) outcome(dealer Score, natural bool, rules Rules) Outcome {
+	score := Evaluate(h.cards)
+	blackJack := score.BlackJack && natural
+
+	switch {
+	case rules.PlayerTwentyOneWins && blackJack:
+		return BlackJackWin
+	case rules.PlayerTwentyOneWins && score.Total == 21:
+		return Win
+	case rules.isCharlie(h):
+		return Win
+	case h.surrendered && dealer.BlackJack:
+		return Lose
+	case rules.DealerBlackJackWinsAll && dealer.BlackJack:
+		return Lose
+	case h.surrendered:
+		return Surrendered
+	case score.Busted:
+		return Lose
+	case blackJack && dealer.BlackJack && rules.DealerWinsTies:
+		return BlackJackWin
+	case blackJack && dealer.BlackJack:
+		return Push
+	case blackJack:
+		return BlackJackWin
+	case dealer.BlackJack:
+		return Lose
+	case rules.FiveCardTrick && len(h.cards) >= 5:
+		return FiveCardTrickWin
+	case rules.DealerPushesOn22 && dealer.Total == 22:
+		return Push
+	case dealer.Busted || score.Total > dealer.Total:
+		return Win
+	case score.Total == dealer.Total && !rules.DealerWinsTies:
+		return Push
+	default:
+		return Lose
 	}
+}
 
-	hasAce := slices.ContainsFunc(h.cards, func(card deck.Card) bool {
-		return card.Rank == deck.Ace
-	})
+func (h *hand) canDoubleDown() bool {
+	return h.canDoubleDownOn(DoubleNineToEleven)
+}
 
-	if sum < 21 && hasAce {
-		sum += 10
+// canDoubleDownOn returns a bool whether the hand can be doubled under the given rule.
+func (h *hand) canDoubleDownOn(rule DoubleRule) bool {
+	switch rule {
+	case DoubleTenToEleven:
+		return len(h.cards) == 2 && slices.Contains([]int{10, 11}, h.sum())
+	case DoubleAnyTwo:
+		return len(h.cards) == 2
+	case DoubleAny:
+		return len(h.cards) >= 2 && !h.busted()
+	default:
+		return len(h.cards) == 2 && slices.Contains([]int{9, 10, 11}, h.sum())
 	}
-
-	return sum
 }
 
-func (h *hand) canDoubleDown() bool {
-	return len(h.cards) == 2 && slices.Contains([]int{9, 10, 11}, h.sum())
+// twentyOneBonus returns the bonus a 21 pays instead of even money.
+// Five cards pay 3:2, six cards 2:1 and seven or more cards 3:1.
+// 6-7-8 and 7-7-7 pay 3:2 mixed, 2:1 suited and 3:1 in spades.
+func twentyOneBonus(cards []deck.Card) (Payout, bool) {
+	if Evaluate(cards).Total != 21 {
+		return Payout{}, false
+	}
+
+	switch {
+	case len(cards) >= 7:
+		return Payout{Win: 3, Stake: 1}, true
+	case len(cards) == 6:
+		return Payout{Win: 2, Stake: 1}, true
+	case len(cards) == 5:
+		return ThreeToTwo, true
+	case len(cards) != 3:
+		return Payout{}, false
+	}
+
+	ranks := []deck.Rank{cards[0].Rank, cards[1].Rank, cards[2].Rank}
+	slices.Sort(ranks)
+	if !slices.Equal(ranks, []deck.Rank{deck.Six, deck.Seven, deck.Eight}) &&
+		!slices.Equal(ranks, []deck.Rank{deck.Seven, deck.Seven, deck.Seven}) {
+		return Payout{}, false
+	}
+
+	suited := cards[0].Suit == cards[1].Suit && cards[1].Suit == cards[2].Suit
+	switch {
+	case suited && cards[0].Suit == deck.Spade:
+		return Payout{Win: 3, Stake: 1}, true
+	case suited:
+		return Payout{Win: 2, Stake: 1}, true
+	default:
+		return ThreeToTwo, true
+	}
 }
 
 func (h *hand) busted() bool {
-	return h.sum() > 21
+	return Evaluate(h.cards).Busted
 }
 
+// cardsPerHand is the capacity new hands start with, most hands never need more cards.
+const cardsPerHand = 5
+
 func newHand(cards []deck.Card, isActive bool, opts ...func(*hand) *hand) *hand {
 	h := &hand{
 		cards:    cards,
@@ -133,7 +332,7 @@ func newHand(cards []deck.Card, isActive bool, opts ...func(*hand) *hand) *hand
 	return h
 }
 
-func newSplitHands(first deck.Card, second deck.Card, previousBet int) *hands {
+func newSplitHands(first deck.Card, second deck.Card, previousBet Money) *hands {
 	f := newHand([]deck.Card{first}, true, withBet(previousBet))
 	s := newHand([]deck.Card{second}, false, withBet(previousBet))
 
@@ -145,8 +344,20 @@ func newSplitHands(first deck.Card, second deck.Card, previousBet int) *hands {
 	}
 }
 
+func newSwitchHands(bet Money) *hands {
+	f := newHand(make([]deck.Card, 0, cardsPerHand), true, withBet(bet))
+	s := newHand(make([]deck.Card, 0, cardsPerHand), false, withBet(bet))
+
+	return &hands{
+		mode:   twoHands,
+		first:  f,
+		second: s,
+		active: f,
+	}
+}
+
 func newHands(opts ...func(*hand) *hand) *hands {
-	first := newHand(make([]deck.Card, 0), true, opts...)
+	first := newHand(make([]deck.Card, 0, cardsPerHand), true, opts...)
 	return &hands{
 		mode:   normal,
 		first:  first,
@@ -155,7 +366,7 @@ func newHands(opts ...func(*hand) *hand) *hands {
 	}
 }
 
-func withBet(bet int) func(*hand) *hand {
+func withBet(bet Money) func(*hand) *hand {
 	return func(hand *hand) *hand {
 		hand.bet = bet
 		return hand
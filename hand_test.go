@@ -164,7 +164,7 @@ func TestHands_doubleDown(t *testing.T) {
 		name      string
 		card      deck.Card
 		hands     *hands
-		wantBet   int
+		wantBet   Money
 		wantCards []deck.Card
 	}{
 		{
@@ -287,6 +287,15 @@ func TestHand_sum(t *testing.T) {
 			},
 			want: 11,
 		},
+		{
+			name: "ace, five and six",
+			cards: []deck.Card{
+				{Rank: deck.Ace, Suit: deck.Spade},
+				{Rank: deck.Five, Suit: deck.Heart},
+				{Rank: deck.Six, Suit: deck.Club},
+			},
+			want: 12,
+		},
 	}
 
 	for _, tt := range tests {
@@ -352,7 +361,7 @@ func TestHand_doubleDown(t *testing.T) {
 		name      string
 		card      deck.Card
 		hand      *hand
-		wantBet   int
+		wantBet   Money
 		wantCards []deck.Card
 	}{
 		{
@@ -392,3 +401,293 @@ func TestHand_doubleDown(t *testing.T) {
 		})
 	}
 }
+
+func TestHand_busted(t *testing.T) {
+	tests := []struct {
+		name  string
+		cards []deck.Card
+		want  bool
+	}{
+		{
+			name: "ace, five and six is not busted",
+			cards: []deck.Card{
+				{Rank: deck.Ace, Suit: deck.Spade},
+				{Rank: deck.Five, Suit: deck.Heart},
+				{Rank: deck.Six, Suit: deck.Club},
+			},
+			want: false,
+		},
+		{
+			name: "ten, six and queen is busted",
+			cards: []deck.Card{
+				{Rank: deck.Ten, Suit: deck.Spade},
+				{Rank: deck.Six, Suit: deck.Heart},
+				{Rank: deck.Queen, Suit: deck.Club},
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &hand{
+				cards: tt.cards,
+			}
+			if got := h.busted(); got != tt.want {
+				t.Errorf("want %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHand_doubleDownFor(t *testing.T) {
+	tests := []struct {
+		name        string
+		amount      Money
+		wantBet     Money
+		wantDoubled Money
+	}{
+		{
+			name:        "for the full bet",
+			amount:      100,
+			wantBet:     200,
+			wantDoubled: 100,
+		},
+		{
+			name:        "for less",
+			amount:      40,
+			wantBet:     140,
+			wantDoubled: 40,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHand([]deck.Card{
+				{Rank: deck.Nine, Suit: deck.Club},
+				{Rank: deck.Two, Suit: deck.Heart},
+			}, true, withBet(100))
+
+			h.doubleDownFor(deck.Card{Rank: deck.Ten, Suit: deck.Club}, tt.amount)
+
+			if len(h.cards) != 3 {
+				t.Errorf("want 3 cards, got %d", len(h.cards))
+			}
+
+			if h.bet != tt.wantBet {
+				t.Errorf("want bet %d, got %d", tt.wantBet, h.bet)
+			}
+
+			if h.doubled != tt.wantDoubled {
+				t.Errorf("want doubled %d, got %d", tt.wantDoubled, h.doubled)
+			}
+		})
+	}
+}
+
+func TestHand_outcome(t *testing.T) {
+	tests := []struct {
+		name      string
+		cards     []deck.Card
+		dealer    []deck.Card
+		splitHand bool
+		rules     Rules
+		want      Outcome
+	}{
+		{
+			name:   "higher total wins",
+			cards:  []deck.Card{{Rank: deck.Ten}, {Rank: deck.Nine}},
+			dealer: []deck.Card{{Rank: deck.Ten}, {Rank: deck.Eight}},
+			want:   Win,
+		},
+		{
+			name:   "lower total loses",
+			cards:  []deck.Card{{Rank: deck.Ten}, {Rank: deck.Seven}},
+			dealer: []deck.Card{{Rank: deck.Ten}, {Rank: deck.Eight}},
+			want:   Lose,
+		},
+		{
+			name:   "same total pushes",
+			cards:  []deck.Card{{Rank: deck.Ten}, {Rank: deck.Eight}},
+			dealer: []deck.Card{{Rank: deck.Nine}, {Rank: deck.Nine}},
+			want:   Push,
+		},
+		{
+			name:   "busted loses even if the dealer busts",
+			cards:  []deck.Card{{Rank: deck.Ten}, {Rank: deck.Six}, {Rank: deck.Ten}},
+			dealer: []deck.Card{{Rank: deck.Ten}, {Rank: deck.Six}, {Rank: deck.Nine}},
+			want:   Lose,
+		},
+		{
+			name:   "dealer busts",
+			cards:  []deck.Card{{Rank: deck.Ten}, {Rank: deck.Two}},
+			dealer: []deck.Card{{Rank: deck.Ten}, {Rank: deck.Six}, {Rank: deck.Nine}},
+			want:   Win,
+		},
+		{
+			name:   "black jack",
+			cards:  []deck.Card{{Rank: deck.Ace}, {Rank: deck.King}},
+			dealer: []deck.Card{{Rank: deck.Ten}, {Rank: deck.Six}, {Rank: deck.Five}},
+			want:   BlackJackWin,
+		},
+		{
+			name:   "black jack against black jack pushes",
+			cards:  []deck.Card{{Rank: deck.Ace}, {Rank: deck.King}},
+			dealer: []deck.Card{{Rank: deck.Ace}, {Rank: deck.Queen}},
+			want:   Push,
+		},
+		{
+			name:   "21 loses against black jack",
+			cards:  []deck.Card{{Rank: deck.Seven}, {Rank: deck.Seven}, {Rank: deck.Seven}},
+			dealer: []deck.Card{{Rank: deck.Ace}, {Rank: deck.Queen}},
+			want:   Lose,
+		},
+		{
+			name:      "21 after a split is no black jack",
+			cards:     []deck.Card{{Rank: deck.Ace}, {Rank: deck.King}},
+			dealer:    []deck.Card{{Rank: deck.Ten}, {Rank: deck.Six}, {Rank: deck.Five}},
+			splitHand: true,
+			want:      Push,
+		},
+		{
+			name:   "dealer 22 pushes",
+			cards:  []deck.Card{{Rank: deck.Ten}, {Rank: deck.Nine}},
+			dealer: []deck.Card{{Rank: deck.Ten}, {Rank: deck.Six}, {Rank: deck.Six}},
+			rules:  Rules{DealerPushesOn22: true},
+			want:   Push,
+		},
+		{
+			name:   "black jack wins against dealer 22",
+			cards:  []deck.Card{{Rank: deck.Ace}, {Rank: deck.King}},
+			dealer: []deck.Card{{Rank: deck.Ten}, {Rank: deck.Six}, {Rank: deck.Six}},
+			rules:  Rules{DealerPushesOn22: true},
+			want:   BlackJackWin,
+		},
+		{
+			name:   "dealer wins ties",
+			cards:  []deck.Card{{Rank: deck.Ten}, {Rank: deck.Eight}},
+			dealer: []deck.Card{{Rank: deck.Nine}, {Rank: deck.Nine}},
+			rules:  Rules{DealerWinsTies: true},
+			want:   Lose,
+		},
+		{
+			name:   "tied black jack wins when the dealer wins ties",
+			cards:  []deck.Card{{Rank: deck.Ace}, {Rank: deck.King}},
+			dealer: []deck.Card{{Rank: deck.Ace}, {Rank: deck.Queen}},
+			rules:  Rules{DealerWinsTies: true},
+			want:   BlackJackWin,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &hand{cards: tt.cards}
+
+			if got := h.outcome(Evaluate(tt.dealer), !tt.splitHand, tt.rules); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHand_canDoubleDownOn(t *testing.T) {
+	nineTwo := []deck.Card{{Rank: deck.Nine}, {Rank: deck.Two}}
+	fiveFour := []deck.Card{{Rank: deck.Five}, {Rank: deck.Four}}
+	tenSeven := []deck.Card{{Rank: deck.Ten}, {Rank: deck.Seven}}
+	threeCards := []deck.Card{{Rank: deck.Two}, {Rank: deck.Three}, {Rank: deck.Five}}
+	busted := []deck.Card{{Rank: deck.Ten}, {Rank: deck.Six}, {Rank: deck.Nine}}
+
+	tests := []struct {
+		name  string
+		rule  DoubleRule
+		cards []deck.Card
+		want  bool
+	}{
+		{name: "11 on nine to eleven", rule: DoubleNineToEleven, cards: nineTwo, want: true},
+		{name: "9 on nine to eleven", rule: DoubleNineToEleven, cards: fiveFour, want: true},
+		{name: "17 on nine to eleven", rule: DoubleNineToEleven, cards: tenSeven, want: false},
+		{name: "9 on ten to eleven", rule: DoubleTenToEleven, cards: fiveFour, want: false},
+		{name: "11 on ten to eleven", rule: DoubleTenToEleven, cards: nineTwo, want: true},
+		{name: "17 on any two", rule: DoubleAnyTwo, cards: tenSeven, want: true},
+		{name: "three cards on any two", rule: DoubleAnyTwo, cards: threeCards, want: false},
+		{name: "three cards on any", rule: DoubleAny, cards: threeCards, want: true},
+		{name: "busted on any", rule: DoubleAny, cards: busted, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &hand{cards: tt.cards}
+
+			if got := h.canDoubleDownOn(tt.rule); got != tt.want {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_twentyOneBonus(t *testing.T) {
+	tests := []struct {
+		name   string
+		cards  []deck.Card
+		want   Payout
+		wantOk bool
+	}{
+		{
+			name:  "three card 21 without bonus",
+			cards: []deck.Card{{Rank: deck.Nine}, {Rank: deck.Two}, {Rank: deck.King}},
+		},
+		{
+			name:  "five cards without 21",
+			cards: []deck.Card{{Rank: deck.Two}, {Rank: deck.Two}, {Rank: deck.Three}, {Rank: deck.Three}, {Rank: deck.Four}},
+		},
+		{
+			name:   "five card 21",
+			cards:  []deck.Card{{Rank: deck.Two}, {Rank: deck.Three}, {Rank: deck.Four}, {Rank: deck.Five}, {Rank: deck.Seven}},
+			want:   ThreeToTwo,
+			wantOk: true,
+		},
+		{
+			name:   "six card 21",
+			cards:  []deck.Card{{Rank: deck.Two}, {Rank: deck.Three}, {Rank: deck.Four}, {Rank: deck.Five}, {Rank: deck.Two}, {Rank: deck.Five}},
+			want:   Payout{Win: 2, Stake: 1},
+			wantOk: true,
+		},
+		{
+			name: "seven card 21",
+			cards: []deck.Card{
+				{Rank: deck.Ace}, {Rank: deck.Two}, {Rank: deck.Three}, {Rank: deck.Four},
+				{Rank: deck.Two}, {Rank: deck.Three}, {Rank: deck.Six},
+			},
+			want:   Payout{Win: 3, Stake: 1},
+			wantOk: true,
+		},
+		{
+			name:   "mixed 6-7-8",
+			cards:  []deck.Card{{Rank: deck.Seven, Suit: deck.Heart}, {Rank: deck.Six, Suit: deck.Club}, {Rank: deck.Eight, Suit: deck.Heart}},
+			want:   ThreeToTwo,
+			wantOk: true,
+		},
+		{
+			name:   "suited 7-7-7",
+			cards:  []deck.Card{{Rank: deck.Seven, Suit: deck.Heart}, {Rank: deck.Seven, Suit: deck.Heart}, {Rank: deck.Seven, Suit: deck.Heart}},
+			want:   Payout{Win: 2, Stake: 1},
+			wantOk: true,
+		},
+		{
+			name:   "spaded 6-7-8",
+			cards:  []deck.Card{{Rank: deck.Six, Suit: deck.Spade}, {Rank: deck.Seven, Suit: deck.Spade}, {Rank: deck.Eight, Suit: deck.Spade}},
+			want:   Payout{Win: 3, Stake: 1},
+			wantOk: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := twentyOneBonus(tt.cards)
+
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("want %s %v, got %s %v", tt.want, tt.wantOk, got, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package blackjack
+
+import "sync"
+
+// EntryKind tells whether an Entry took money from or gave money to a Wallet.
+type EntryKind int
+
+const (
+	Debit EntryKind = iota
+	Credit
+)
+
+func (k EntryKind) String() string {
+	if k == Credit {
+		return "credit"
+	}
+	return "debit"
+}
+
+// Entry is one booking in the Ledger.
+type Entry struct {
+	Transaction
+	Kind EntryKind
+	// PlayerID identifies the player, see Player.ID. Player is only the name, which several players may share.
+	PlayerID uint64
+	Player   string
+	// Balance is the balance of the player's wallet after the booking.
+	Balance Money
+}
+
+// Ledger is an append-only record of every debit and credit made at a Table.
+type Ledger struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// Entries returns a copy of all entries in the order they were booked.
+func (l *Ledger) Entries() []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]Entry(nil), l.entries...)
+}
+
+// Round returns the entries booked in the given round.
+func (l *Ledger) Round(round int) []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var out []Entry
+	for _, e := range l.entries {
+		if e.Round == round {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Net returns the sum of all credits minus all debits of the player with the given ID.
+// A negative result is money the player lost to the house.
+func (l *Ledger) Net(player uint64) Money {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var net Money
+	for _, e := range l.entries {
+		if e.PlayerID != player {
+			continue
+		}
+		if e.Kind == Credit {
+			net += e.Amount
+		} else {
+			net -= e.Amount
+		}
+	}
+	return net
+}
+
+// record appends an entry. It does nothing for a nil Ledger so players can be used without a Table.
+func (l *Ledger) record(e Entry) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries = append(l.entries, e)
+}
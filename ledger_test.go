@@ -0,0 +1,54 @@
+package blackjack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLedger(t *testing.T) {
+	entries := []Entry{
+		{Transaction: Transaction{Round: 0, Reason: ReasonBet, Amount: 100}, Kind: Debit, PlayerID: 1, Player: "One", Balance: 400},
+		{Transaction: Transaction{Round: 0, Reason: ReasonBet, Amount: 50}, Kind: Debit, PlayerID: 2, Player: "One", Balance: 450},
+		{Transaction: Transaction{Round: 0, Reason: ReasonWin, Amount: 200}, Kind: Credit, PlayerID: 1, Player: "One", Balance: 600},
+		{Transaction: Transaction{Round: 1, Reason: ReasonBet, Amount: 100}, Kind: Debit, PlayerID: 1, Player: "One", Balance: 500},
+		{Transaction: Transaction{Round: 1, Hand: 1, Reason: ReasonSplit, Amount: 100}, Kind: Debit, PlayerID: 1, Player: "One", Balance: 400},
+	}
+	l := &Ledger{}
+	for _, e := range entries {
+		l.record(e)
+	}
+
+	t.Run("entries", func(t *testing.T) {
+		got := l.Entries()
+		if !reflect.DeepEqual(got, entries) {
+			t.Errorf("want %#v, got %#v", entries, got)
+		}
+
+		got[0].Amount = 0
+		if l.Entries()[0].Amount != 100 {
+			t.Errorf("entries should not be changed through the returned slice")
+		}
+	})
+
+	t.Run("round", func(t *testing.T) {
+		want := entries[3:]
+		if got := l.Round(1); !reflect.DeepEqual(got, want) {
+			t.Errorf("want %#v, got %#v", want, got)
+		}
+	})
+
+	t.Run("net", func(t *testing.T) {
+		if got := l.Net(1); got != -100 {
+			t.Errorf("want %d, got %d", -100, got)
+		}
+
+		if got := l.Net(2); got != -50 {
+			t.Errorf("want %d, got %d", -50, got)
+		}
+	})
+
+	t.Run("nil ledger does not record", func(t *testing.T) {
+		var l *Ledger
+		l.record(entries[0])
+	})
+}
@@ -0,0 +1,176 @@
+package blackjack
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidMoney        = errors.New("invalid money")
+	ErrInvalidDenomination = errors.New("bet can not be made with the table's chips")
+)
+
+// Money is an amount in minor units of the currency, e.g. cents. 750 is 7.50.
+type Money int64
+
+// Unit is one major unit of the currency, e.g. one dollar.
+const Unit Money = 100
+
+// String formats the money with two decimals like 7.50.
+func (m Money) String() string {
+	sign := ""
+	if m < 0 {
+		sign = "-"
+		m = -m
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, m/Unit, m%Unit)
+}
+
+// ParseMoney parses an amount like 7.5, 7.50 or 7 into Money.
+// It returns ErrInvalidMoney if the amount has more than two decimals, is not a number or does not fit into Money.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	major, minor, hasMinor := strings.Cut(s, ".")
+	if major == "" || major[0] < '0' || major[0] > '9' || (hasMinor && (minor == "" || len(minor) > 2)) {
+		return 0, ErrInvalidMoney
+	}
+
+	m, err := strconv.ParseInt(major, 10, 64)
+	if err != nil || m > math.MaxInt64/int64(Unit) {
+		return 0, ErrInvalidMoney
+	}
+	out := Money(m) * Unit
+
+	if hasMinor {
+		if len(minor) == 1 {
+			minor += "0"
+		}
+		c, err := strconv.ParseUint(minor, 10, 8)
+		if err != nil || out > math.MaxInt64-Money(c) {
+			return 0, ErrInvalidMoney
+		}
+		out += Money(c)
+	}
+
+	if negative {
+		out = -out
+	}
+	return out, nil
+}
+
+// Rounding decides what happens to fractions of a minor unit when a payout is computed.
+type Rounding int
+
+const (
+	// RoundDown drops the fraction, which is in favour of the house.
+	RoundDown Rounding = iota
+	// RoundHalfUp rounds half a minor unit or more up.
+	RoundHalfUp
+	// RoundUp rounds every fraction up, which is in favour of the player.
+	RoundUp
+)
+
+// Payout is the odds a bet pays, e.g. 3 to 2 for a black jack.
+type Payout struct {
+	Win   int64
+	Stake int64
+}
+
+var (
+	ThreeToTwo = Payout{Win: 3, Stake: 2}
+	SixToFive  = Payout{Win: 6, Stake: 5}
+	EvenMoney  = Payout{Win: 1, Stake: 1}
+)
+
+func (p Payout) String() string {
+	return fmt.Sprintf("%d:%d", p.Win, p.Stake)
+}
+
+// Of returns the winnings for the stake, without the stake itself, rounded to a whole minor unit.
+// A Payout without a positive Stake pays Win to 1.
+func (p Payout) Of(stake Money, rounding Rounding) Money {
+	p = p.normalized()
+	n := int64(stake) * p.Win
+	q, r := n/p.Stake, n%p.Stake
+
+	switch {
+	case r == 0:
+	case rounding == RoundUp:
+		q++
+	case rounding == RoundHalfUp && 2*r >= p.Stake:
+		q++
+	}
+
+	return Money(q)
+}
+
+// normalized returns the payout with a Stake of 1 if it has none, e.g. {Win: 25} pays 25:1.
+func (p Payout) normalized() Payout {
+	if p.Stake <= 0 {
+		p.Stake = 1
+	}
+	return p
+}
+
+// composable reports whether the amount can be put together using any number of chips of the given denominations.
+// Every multiple of the greatest common divisor of the chips above the Frobenius bound of the chips can, so only
+// smaller amounts are checked one by one.
+func composable(amount Money, chips []Money) bool {
+	if amount < 0 {
+		return false
+	}
+
+	chips = slices.DeleteFunc(slices.Clone(chips), func(c Money) bool { return c <= 0 })
+	if len(chips) == 0 {
+		return false
+	}
+
+	step := chips[0]
+	for _, c := range chips[1:] {
+		step = gcd(step, c)
+	}
+	if amount%step != 0 {
+		return false
+	}
+
+	smallest, largest := slices.Min(chips)/step, slices.Max(chips)/step
+	if smallest == 1 || amount/step >= frobeniusBound(smallest, largest) {
+		return true
+	}
+
+	n := int(amount / step)
+	reachable := make([]bool, n+1)
+	reachable[0] = true
+	for i := 1; i <= n; i++ {
+		for _, c := range chips {
+			if u := int(c / step); u <= i && reachable[i-u] {
+				reachable[i] = true
+				break
+			}
+		}
+	}
+	return reachable[n]
+}
+
+// frobeniusBound returns Schur's bound (a-1)(b-1) for the smallest and largest of chips without a common divisor.
+// Every amount from the bound on can be put together with the chips.
+func frobeniusBound(smallest, largest Money) Money {
+	if largest-1 > math.MaxInt64/(smallest-1) {
+		return math.MaxInt64
+	}
+	return (smallest - 1) * (largest - 1)
+}
+
+func gcd(a, b Money) Money {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
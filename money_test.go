@@ -0,0 +1,130 @@
+package blackjack
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{money: 0, want: "0.00"},
+		{money: 5, want: "0.05"},
+		{money: 750, want: "7.50"},
+		{money: 500 * Unit, want: "500.00"},
+		{money: -1250, want: "-12.50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.money.String(); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Money
+		wantErr error
+	}{
+		{in: "7", want: 700},
+		{in: "7.5", want: 750},
+		{in: "7.50", want: 750},
+		{in: "0.05", want: 5},
+		{in: " 12.34 ", want: 1234},
+		{in: "-3.20", want: -320},
+		{in: "7.505", wantErr: ErrInvalidMoney},
+		{in: "7.", wantErr: ErrInvalidMoney},
+		{in: ".50", wantErr: ErrInvalidMoney},
+		{in: "seven", wantErr: ErrInvalidMoney},
+		{in: "7.x", wantErr: ErrInvalidMoney},
+		{in: "", wantErr: ErrInvalidMoney},
+		{in: "--5", wantErr: ErrInvalidMoney},
+		{in: "-+5", wantErr: ErrInvalidMoney},
+		{in: "+5", wantErr: ErrInvalidMoney},
+		{in: "92233720368547758.07", want: math.MaxInt64},
+		{in: "92233720368547758.08", wantErr: ErrInvalidMoney},
+		{in: "92233720368547759", wantErr: ErrInvalidMoney},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseMoney(tt.in)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("want %#v, got %#v", tt.wantErr, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("want %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPayout_Of(t *testing.T) {
+	tests := []struct {
+		name     string
+		payout   Payout
+		stake    Money
+		rounding Rounding
+		want     Money
+	}{
+		{name: "3:2 on 5.00", payout: ThreeToTwo, stake: 500, rounding: RoundDown, want: 750},
+		{name: "3:2 on 5.01 rounded down", payout: ThreeToTwo, stake: 501, rounding: RoundDown, want: 751},
+		{name: "3:2 on 5.01 rounded half up", payout: ThreeToTwo, stake: 501, rounding: RoundHalfUp, want: 752},
+		{name: "3:2 on 5.01 rounded up", payout: ThreeToTwo, stake: 501, rounding: RoundUp, want: 752},
+		{name: "6:5 on 10.00", payout: SixToFive, stake: 1000, rounding: RoundDown, want: 1200},
+		{name: "6:5 on 5.03 rounded down", payout: SixToFive, stake: 503, rounding: RoundDown, want: 603},
+		{name: "6:5 on 5.03 rounded half up", payout: SixToFive, stake: 503, rounding: RoundHalfUp, want: 604},
+		{name: "6:5 on 5.02 rounded half up", payout: SixToFive, stake: 502, rounding: RoundHalfUp, want: 602},
+		{name: "even money", payout: EvenMoney, stake: 333, rounding: RoundDown, want: 333},
+		{name: "half of 5.01 rounded down", payout: half, stake: 501, rounding: RoundDown, want: 250},
+		{name: "half of 5.01 rounded half up", payout: half, stake: 501, rounding: RoundHalfUp, want: 251},
+		{name: "without stake pays to 1", payout: Payout{Win: 25}, stake: 200, rounding: RoundDown, want: 5000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.payout.Of(tt.stake, tt.rounding); got != tt.want {
+				t.Errorf("want %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_composable(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Money
+		chips  []Money
+		want   bool
+	}{
+		{name: "single chip", amount: 500, chips: []Money{500}, want: true},
+		{name: "several chips", amount: 3000, chips: []Money{100, 500, 2500}, want: true},
+		{name: "not a multiple of the smallest chip", amount: 750, chips: []Money{500, 2500}, want: false},
+		{name: "half chips", amount: 250, chips: []Money{50, 100}, want: true},
+		{name: "not reachable although the greatest common divisor fits", amount: 300, chips: []Money{200, 500}, want: false},
+		{name: "reachable with two different chips", amount: 700, chips: []Money{200, 500}, want: true},
+		{name: "no chips", amount: 500, chips: nil, want: false},
+		{name: "invalid chips are ignored", amount: 500, chips: []Money{0, -100, 100}, want: true},
+		{name: "largest amount not reachable", amount: 700, chips: []Money{300, 500}, want: false},
+		{name: "reachable from the frobenius bound on", amount: 800, chips: []Money{300, 500}, want: true},
+		{name: "huge amount", amount: 500 * (1 << 50), chips: []Money{500, 2500}, want: true},
+		{name: "huge amount not a multiple", amount: 500*(1<<50) + 100, chips: []Money{500, 2500}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := composable(tt.amount, tt.chips); got != tt.want {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
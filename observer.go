@@ -0,0 +1,73 @@
+package blackjack
+
+import "github.com/Hydoc/deck"
+
+// Observer watches the cards of the shoe, for example to count them. It is registered with WithObserver.
+type Observer interface {
+	// Dealt is called for every card once it is seen. The dealer's face down cards are seen when they are turned
+	// over at the end of the round.
+	Dealt(card deck.Card)
+	// Shuffled is called whenever the table starts a fresh shoe of the given amount of cards. A shoe running out
+	// during a round is replaced right away, but the observers are told at the end of the round, followed by the
+	// cards dealt from the fresh shoe.
+	Shuffled(cards int)
+}
+
+// WithObserver is an option for New to show every card seen at the table and every shuffle to the observer.
+func WithObserver(o Observer) func(t *Table) *Table {
+	return func(t *Table) *Table {
+		t.observers = append(t.observers, o)
+		return t
+	}
+}
+
+// reshuffle is a shoe started during a round because the old one ran out. The observers learn about it once the
+// round is over, after the dealer's face down cards of the old shoe are turned over. Until then the cards dealt
+// from the fresh shoe are withheld, so that no card is counted against the wrong shoe.
+type reshuffle struct {
+	// cards is the size of the fresh shoe.
+	cards int
+	// dealer is the amount of the dealer's cards dealt from the old shoe.
+	dealer   int
+	withheld []deck.Card
+}
+
+// show passes the cards to every observer, or withholds them after a reshuffle during the round.
+func (t *Table) show(cards ...deck.Card) {
+	if t.reshuffled != nil {
+		t.reshuffled.withheld = append(t.reshuffled.withheld, cards...)
+		return
+	}
+	t.notify(cards...)
+}
+
+// reveal turns over the dealer's face down cards at the end of the round. After a reshuffle during the round the
+// face down cards of the old shoe come first, then the fresh shoe and the cards withheld from it.
+func (t *Table) reveal() {
+	cards := t.dealer.hand.cards
+	faceUp := min(t.rules.faceUp(), len(cards))
+
+	r := t.reshuffled
+	if r == nil {
+		t.notify(cards[faceUp:]...)
+		return
+	}
+
+	t.reshuffled = nil
+	old := max(faceUp, r.dealer)
+	t.notify(cards[faceUp:old]...)
+	for _, o := range t.observers {
+		o.Shuffled(r.cards)
+	}
+	t.notify(r.withheld...)
+	t.notify(cards[old:]...)
+}
+
+// notify passes the cards to every observer.
+func (t *Table) notify(cards ...deck.Card) {
+	for _, o := range t.observers {
+		for _, card := range cards {
+			o.Dealt(card)
+		}
+	}
+}
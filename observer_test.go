@@ -0,0 +1,128 @@
+package blackjack
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Hydoc/deck"
+)
+
+type recorder struct {
+	dealt    []deck.Card
+	shuffled []int
+}
+
+func (r *recorder) Dealt(card deck.Card) {
+	r.dealt = append(r.dealt, card)
+}
+
+func (r *recorder) Shuffled(cards int) {
+	r.shuffled = append(r.shuffled, cards)
+}
+
+func TestWithObserver(t *testing.T) {
+	cards := []deck.Card{
+		{Rank: deck.Ten, Suit: deck.Heart},
+		{Rank: deck.Six, Suit: deck.Club},
+		{Rank: deck.Seven, Suit: deck.Heart},
+		{Rank: deck.King, Suit: deck.Club},
+		{Rank: deck.Two, Suit: deck.Spade},
+		{Rank: deck.Three, Suit: deck.Diamond},
+	}
+
+	tests := []struct {
+		name      string
+		rules     Rules
+		wantStart []deck.Card
+		wantEnd   []deck.Card
+	}{
+		{
+			name:      "hole card is seen at the end of the round",
+			wantStart: []deck.Card{cards[0], cards[1], cards[2]},
+			wantEnd:   []deck.Card{cards[0], cards[1], cards[2], cards[4], cards[3], cards[5]},
+		},
+		{
+			name:      "exposed dealer cards are seen when dealt",
+			rules:     Rules{DealerExposed: true},
+			wantStart: []deck.Card{cards[0], cards[1], cards[2], cards[3]},
+			wantEnd:   []deck.Card{cards[0], cards[1], cards[2], cards[3], cards[4], cards[5]},
+		},
+		{
+			name:      "hidden dealer cards are seen at the end of the round",
+			rules:     Rules{DealerHidden: true},
+			wantStart: []deck.Card{cards[0], cards[2]},
+			wantEnd:   []deck.Card{cards[0], cards[2], cards[4], cards[1], cards[3], cards[5]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{}
+			table := New(WithRules(tt.rules), WithObserver(r))
+			if want := []int{312}; !reflect.DeepEqual(r.shuffled, want) {
+				t.Errorf("want %#v, got %#v", want, r.shuffled)
+			}
+
+			table.deck = stack(cards...)
+			player := NewPlayer(100 * Unit)
+			table.Join(player)
+			table.Bet(player, 10*Unit)
+			table.Start()
+
+			if !reflect.DeepEqual(r.dealt, tt.wantStart) {
+				t.Errorf("want %#v, got %#v", tt.wantStart, r.dealt)
+			}
+
+			table.Hit()
+			table.Stand()
+
+			if !reflect.DeepEqual(r.dealt, tt.wantEnd) {
+				t.Errorf("want %#v, got %#v", tt.wantEnd, r.dealt)
+			}
+		})
+	}
+}
+
+// sequence records the cards and shuffles in the order the observer is told about them.
+type sequence []string
+
+func (s *sequence) Dealt(card deck.Card) {
+	*s = append(*s, card.String())
+}
+
+func (s *sequence) Shuffled(int) {
+	*s = append(*s, "shuffled")
+}
+
+func TestWithObserver_ShoeRunsOut(t *testing.T) {
+	var s sequence
+	table := New(WithObserver(&s))
+	s = nil
+	table.deck = stack(
+		deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+		deck.Card{Rank: deck.Six, Suit: deck.Club},
+		deck.Card{Rank: deck.Two, Suit: deck.Spade},
+		deck.Card{Rank: deck.King, Suit: deck.Club},
+	)
+	player := NewPlayer(100 * Unit)
+	table.Join(player)
+	table.Bet(player, 10*Unit)
+	table.Start()
+
+	// the shoe runs out while the hole card of the old shoe is still face down
+	table.Hit()
+	hit := player.Hands()[0][2]
+	if table.InProgress() {
+		table.Stand()
+	}
+
+	want := []string{"Ten of Hearts", "Six of Clubs", "Two of Spades", "King of Clubs", "shuffled", hit.String()}
+	if len(s) < len(want) || !reflect.DeepEqual([]string(s[:len(want)]), want) {
+		t.Errorf("want %#v first, got %#v", want, s)
+	}
+
+	// every card seen after the shuffle is one of the fresh shoe
+	if drawn := 6*52 - len(table.deck); len(s)-5 != drawn {
+		t.Errorf("want %d cards of the fresh shoe, got %d", drawn, len(s)-5)
+	}
+}
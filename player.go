@@ -1,30 +1,156 @@
 package blackjack
 
-import "github.com/Hydoc/deck"
+import (
+	"errors"
+	"slices"
+	"sync/atomic"
+
+	"github.com/Hydoc/deck"
+)
 
 type Mode = int
 
 const (
 	normal Mode = iota
 	split
+	// twoHands is played at Blackjack Switch where every player plays two hands from the start.
+	twoHands
 )
 
+// playerIDs counts the players created with NewPlayer to give each of them its own ID.
+var playerIDs atomic.Uint64
+
 // Player represents one player in the game.
 type Player struct {
 	Name string
 
-	wallet int
+	id     uint64
+	wallet Wallet
+	ledger *Ledger
+	round  int
 
 	hands *hands
 }
 
+// ID returns the number identifying the player in the Ledger. Every player created with NewPlayer has its own,
+// even if the names are the same.
+func (p *Player) ID() uint64 {
+	return p.id
+}
+
+// Balance returns the balance of the player's wallet.
+func (p *Player) Balance() Money {
+	return p.wallet.Balance()
+}
+
+// Cards returns the cards of the active hand, nil if no hand is active. The cards must not be modified.
+func (p *Player) Cards() []deck.Card {
+	if p.hands.active == nil {
+		return nil
+	}
+	return p.hands.active.cards
+}
+
+// Hands returns a copy of the cards of every hand of the player, the hand played first comes first.
+func (p *Player) Hands() [][]deck.Card {
+	all := p.hands.all()
+	out := make([][]deck.Card, 0, len(all))
+	for _, h := range all {
+		out = append(out, slices.Clone(h.cards))
+	}
+	return out
+}
+
+// ActiveHand returns the index of the active hand in Hands, -1 if no hand is active.
+func (p *Player) ActiveHand() int {
+	if p.hands.active == nil {
+		return -1
+	}
+	return p.hands.activeID()
+}
+
+// DoubleDown doubles the bet of the active hand and hits the card. The additional bet is taken from the wallet.
 func (p *Player) DoubleDown(card deck.Card) error {
 	if !p.canDoubleDown() {
 		return ErrNotAllowed
 	}
 
-	p.wallet -= p.hands.active.bet
-	p.hands.doubleDown(card)
+	return p.doubleDown(card, p.hands.active.bet)
+}
+
+// DoubleDownFor doubles the active hand for any amount up to the original bet and hits the card.
+// The amount is taken from the wallet.
+func (p *Player) DoubleDownFor(card deck.Card, amount Money) error {
+	if !p.canDoubleDownFor(amount) {
+		return ErrNotAllowed
+	}
+
+	return p.doubleDown(card, amount)
+}
+
+// doubleDown takes the amount from the wallet, adds it to the bet of the active hand and hits the card.
+func (p *Player) doubleDown(card deck.Card, amount Money) error {
+	if err := p.debit(p.hands.activeID(), ReasonDoubleDown, amount); err != nil {
+		return err
+	}
+	p.hands.doubleDownFor(card, amount)
+
+	return nil
+}
+
+// Split splits the active pair into two hands. The second hand is played with the same bet which is taken from the wallet.
+func (p *Player) Split() error {
+	if !p.canSplit() {
+		return ErrNotAllowed
+	}
+
+	h, err := p.hands.active.split()
+	if err != nil {
+		return err
+	}
+
+	if err := p.debit(1, ReasonSplit, h.second.bet); err != nil {
+		return err
+	}
+	p.hands = h
+
+	return nil
+}
+
+// splitFree splits the active pair into two hands. The house funds the second hand with a free bet.
+func (p *Player) splitFree() error {
+	if !p.hands.canSplit() {
+		return ErrNotAllowed
+	}
+
+	h, err := p.hands.active.split()
+	if err != nil {
+		return err
+	}
+	h.second.freeBet, h.second.bet = h.second.bet, 0
+	p.hands = h
+
+	return nil
+}
+
+// Surrender gives up the active hand and ends the turn. Only the first two cards of a hand which was not split can be surrendered.
+func (p *Player) Surrender() error {
+	if !p.hands.canSurrender() {
+		return ErrNotAllowed
+	}
+
+	p.hands.surrender()
+
+	return nil
+}
+
+// Switch swaps the second cards of the two hands at Blackjack Switch. It is only allowed before the first hand is played.
+func (p *Player) Switch() error {
+	if !p.hands.canSwitch() {
+		return ErrNotAllowed
+	}
+
+	p.hands.switchCards()
 
 	return nil
 }
@@ -52,8 +178,146 @@ func (p *Player) canDoubleDown() bool {
 	return p.canBetTheSameAmountAgain() && p.hands.canDoubleDown()
 }
 
+// bet starts new hands with the given bet and takes it from the wallet.
+func (p *Player) bet(amount Money) error {
+	if amount <= 0 {
+		return ErrNotAllowed
+	}
+
+	if amount > p.wallet.Balance() {
+		return ErrInsufficientFunds
+	}
+
+	if err := p.debit(0, ReasonBet, amount); err != nil {
+		return err
+	}
+	p.hands = newHands(withBet(amount))
+
+	return nil
+}
+
+// betTwoHands starts two hands for Blackjack Switch with the given bet each and takes both bets from the wallet.
+func (p *Player) betTwoHands(amount Money) error {
+	if amount <= 0 {
+		return ErrNotAllowed
+	}
+
+	if 2*amount > p.wallet.Balance() {
+		return ErrInsufficientFunds
+	}
+
+	if err := p.debit(0, ReasonBet, amount); err != nil {
+		return err
+	}
+	if err := p.debit(1, ReasonBet, amount); err != nil {
+		return errors.Join(err, p.credit(0, ReasonRefund, amount))
+	}
+	p.hands = newSwitchHands(amount)
+
+	return nil
+}
+
+// placeSideBet takes the amount of the side bet from the wallet and places it next to the main bet.
+func (p *Player) placeSideBet(bet SideBet, amount Money) error {
+	if len(p.hands.first.cards) > 0 {
+		return ErrNoMainBet
+	}
+
+	if err := bet.Validate(amount, p.hands.first.bet); err != nil {
+		return err
+	}
+
+	if err := p.debit(0, ReasonSideBet, amount); err != nil {
+		return err
+	}
+	p.hands.sideBets = append(p.hands.sideBets, placedSideBet{bet: bet, amount: amount})
+
+	return nil
+}
+
+// pending returns the bets and side bets placed for a round which was not dealt yet.
+func (p *Player) pending() Money {
+	if p.hands == nil || len(p.hands.first.cards) > 0 {
+		return 0
+	}
+
+	var sum Money
+	for _, h := range p.hands.all() {
+		sum += h.bet
+	}
+	for _, b := range p.hands.sideBets {
+		sum += b.amount
+	}
+	return sum
+}
+
+// refund credits back the bets and side bets placed for a round which was not dealt yet and clears the hands.
+func (p *Player) refund() error {
+	if p.hands == nil || len(p.hands.first.cards) > 0 {
+		return nil
+	}
+
+	var err error
+	for i, h := range p.hands.all() {
+		if h.bet > 0 {
+			err = errors.Join(err, p.credit(i, ReasonRefund, h.bet))
+		}
+	}
+	for _, b := range p.hands.sideBets {
+		err = errors.Join(err, p.credit(0, ReasonRefund, b.amount))
+	}
+	p.hands = newHands()
+
+	return err
+}
+
+// debit takes the amount for the given hand from the wallet and records it in the ledger.
+func (p *Player) debit(hand int, reason Reason, amount Money) error {
+	tx := Transaction{
+		Round:  p.round,
+		Hand:   hand,
+		Reason: reason,
+		Amount: amount,
+	}
+	if err := p.wallet.Debit(tx); err != nil {
+		return err
+	}
+	p.record(Debit, tx)
+	return nil
+}
+
+// credit pays the amount for the given hand to the wallet and records it in the ledger.
+func (p *Player) credit(hand int, reason Reason, amount Money) error {
+	tx := Transaction{
+		Round:  p.round,
+		Hand:   hand,
+		Reason: reason,
+		Amount: amount,
+	}
+	if err := p.wallet.Credit(tx); err != nil {
+		return err
+	}
+	p.record(Credit, tx)
+	return nil
+}
+
+func (p *Player) record(kind EntryKind, tx Transaction) {
+	p.ledger.record(Entry{
+		Transaction: tx,
+		Kind:        kind,
+		PlayerID:    p.id,
+		Player:      p.Name,
+		Balance:     p.wallet.Balance(),
+	})
+}
+
+// canDoubleDownFor returns a bool whether the player can double down for the given amount.
+func (p *Player) canDoubleDownFor(amount Money) bool {
+	return amount > 0 && amount <= p.hands.active.bet && amount <= p.wallet.Balance() && p.hands.canDoubleDown()
+}
+
 func (p *Player) canBetTheSameAmountAgain() bool {
-	return p.hands.active.bet <= p.wallet
+	return p.hands.active.bet <= p.wallet.Balance()
 }
 
 func (p *Player) hasBlackJack() bool {
@@ -69,10 +333,12 @@ func (p *Player) isDone() bool {
 }
 
 // NewPlayer creates a pointer to the new player with the passed configuration.
-func NewPlayer(wallet int, opts ...func(p *Player) *Player) *Player {
+// The player gets a MemoryWallet with the given balance unless WithWallet is passed.
+func NewPlayer(wallet Money, opts ...func(p *Player) *Player) *Player {
 	p := &Player{
+		id:     playerIDs.Add(1),
 		hands:  newHands(),
-		wallet: wallet,
+		wallet: NewMemoryWallet(wallet),
 	}
 	for _, opt := range opts {
 		opt(p)
@@ -87,3 +353,11 @@ func WithName(name string) func(p *Player) *Player {
 		return p
 	}
 }
+
+// WithWallet is an option for NewPlayer to play with the given wallet instead of a MemoryWallet.
+func WithWallet(wallet Wallet) func(p *Player) *Player {
+	return func(p *Player) *Player {
+		p.wallet = wallet
+		return p
+	}
+}
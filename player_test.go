@@ -1,6 +1,7 @@
 package blackjack
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -8,7 +9,7 @@ import (
 )
 
 func TestNewPlayer(t *testing.T) {
-	wallet := 200
+	wallet := Money(200)
 	p := NewPlayer(
 		wallet,
 		WithName("Test"),
@@ -18,9 +19,13 @@ func TestNewPlayer(t *testing.T) {
 		t.Errorf("name should be Test")
 	}
 
-	if p.wallet != wallet {
+	if p.wallet.Balance() != wallet {
 		t.Errorf("wallet should be %d", wallet)
 	}
+
+	if other := NewPlayer(wallet, WithName("Test")); other.ID() == p.ID() || p.ID() == 0 {
+		t.Errorf("want players of the same name to have their own ID, got %d and %d", p.ID(), other.ID())
+	}
 }
 
 func TestPlayer_CanDoubleDown(t *testing.T) {
@@ -32,7 +37,7 @@ func TestPlayer_CanDoubleDown(t *testing.T) {
 		{
 			name: "can double down",
 			player: &Player{
-				wallet: 300,
+				wallet: NewMemoryWallet(300),
 				hands: &hands{
 					active: &hand{
 						bet: 200,
@@ -48,7 +53,7 @@ func TestPlayer_CanDoubleDown(t *testing.T) {
 		{
 			name: "not possible due to wallet",
 			player: &Player{
-				wallet: 199,
+				wallet: NewMemoryWallet(199),
 				hands: &hands{
 					active: &hand{
 						bet: 200,
@@ -64,7 +69,7 @@ func TestPlayer_CanDoubleDown(t *testing.T) {
 		{
 			name: "not possible due to higher than allowed",
 			player: &Player{
-				wallet: 500,
+				wallet: NewMemoryWallet(500),
 				hands: &hands{
 					active: &hand{
 						bet: 200,
@@ -97,7 +102,7 @@ func TestPlayer_CanSplit(t *testing.T) {
 		{
 			name: "can split",
 			player: &Player{
-				wallet: 200,
+				wallet: NewMemoryWallet(200),
 				hands: &hands{
 					active: &hand{
 						bet: 200,
@@ -113,6 +118,7 @@ func TestPlayer_CanSplit(t *testing.T) {
 		{
 			name: "can not split with different ranks",
 			player: &Player{
+				wallet: NewMemoryWallet(0),
 				hands: &hands{
 					active: &hand{
 						cards: []deck.Card{
@@ -127,6 +133,7 @@ func TestPlayer_CanSplit(t *testing.T) {
 		{
 			name: "can not split with more than two cards",
 			player: &Player{
+				wallet: NewMemoryWallet(0),
 				hands: &hands{
 					active: &hand{
 						cards: []deck.Card{
@@ -142,7 +149,7 @@ func TestPlayer_CanSplit(t *testing.T) {
 		{
 			name: "can not split due to wallet",
 			player: &Player{
-				wallet: 199,
+				wallet: NewMemoryWallet(199),
 				hands: &hands{
 					active: &hand{
 						bet: 200,
@@ -339,14 +346,14 @@ func TestPlayer_DoubleDown(t *testing.T) {
 		name       string
 		card       deck.Card
 		player     *Player
-		wantWallet int
+		wantWallet Money
 		wantErr    error
 	}{
 		{
 			name: "double down correctly",
 			card: deck.Card{Rank: deck.Eight, Suit: deck.Heart},
 			player: &Player{
-				wallet: 400,
+				wallet: NewMemoryWallet(400),
 				hands: &hands{
 					active: &hand{
 						cards: []deck.Card{
@@ -364,7 +371,7 @@ func TestPlayer_DoubleDown(t *testing.T) {
 			name: "not double down when player cannot bet the same amount again",
 			card: deck.Card{Rank: deck.Eight, Suit: deck.Heart},
 			player: &Player{
-				wallet: 100,
+				wallet: NewMemoryWallet(100),
 				hands: &hands{
 					active: &hand{
 						cards: []deck.Card{
@@ -383,7 +390,7 @@ func TestPlayer_DoubleDown(t *testing.T) {
 			name: "not double down when not allowed because of the cards",
 			card: deck.Card{Rank: deck.Eight, Suit: deck.Heart},
 			player: &Player{
-				wallet: 500,
+				wallet: NewMemoryWallet(500),
 				hands: &hands{
 					active: &hand{
 						cards: []deck.Card{
@@ -408,8 +415,309 @@ func TestPlayer_DoubleDown(t *testing.T) {
 				t.Errorf("want err %#v, got %#v", tt.wantErr, err)
 			}
 
-			if tt.player.wallet != tt.wantWallet {
-				t.Errorf("want wallet %#v, got %#v", tt.wantWallet, tt.player.wallet)
+			if tt.player.wallet.Balance() != tt.wantWallet {
+				t.Errorf("want wallet %#v, got %#v", tt.wantWallet, tt.player.wallet.Balance())
+			}
+		})
+	}
+}
+
+func TestPlayer_Split(t *testing.T) {
+	tests := []struct {
+		name       string
+		player     *Player
+		wantErr    error
+		wantWallet Money
+		wantMode   Mode
+	}{
+		{
+			name: "split correctly",
+			player: &Player{
+				wallet: NewMemoryWallet(300),
+				hands: newHands(withBet(200), func(h *hand) *hand {
+					h.cards = []deck.Card{
+						{Rank: deck.Ten, Suit: deck.Spade},
+						{Rank: deck.Ten, Suit: deck.Heart},
+					}
+					return h
+				}),
+			},
+			wantWallet: 100,
+			wantMode:   split,
+		},
+		{
+			name: "not split when player cannot bet the same amount again",
+			player: &Player{
+				wallet: NewMemoryWallet(100),
+				hands: newHands(withBet(200), func(h *hand) *hand {
+					h.cards = []deck.Card{
+						{Rank: deck.Ten, Suit: deck.Spade},
+						{Rank: deck.Ten, Suit: deck.Heart},
+					}
+					return h
+				}),
+			},
+			wantErr:    ErrNotAllowed,
+			wantWallet: 100,
+			wantMode:   normal,
+		},
+		{
+			name: "not split twice",
+			player: &Player{
+				wallet: NewMemoryWallet(1000),
+				hands: func() *hands {
+					h := newSplitHands(
+						deck.Card{Rank: deck.Ten, Suit: deck.Spade},
+						deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+						200,
+					)
+					h.first.hit(deck.Card{Rank: deck.Ten, Suit: deck.Club})
+					return h
+				}(),
+			},
+			wantErr:    ErrNotAllowed,
+			wantWallet: 1000,
+			wantMode:   split,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.player.Split()
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("want err %#v, got %#v", tt.wantErr, err)
+			}
+
+			if tt.player.wallet.Balance() != tt.wantWallet {
+				t.Errorf("want wallet %#v, got %#v", tt.wantWallet, tt.player.wallet.Balance())
+			}
+
+			if tt.player.hands.mode != tt.wantMode {
+				t.Errorf("want mode %#v, got %#v", tt.wantMode, tt.player.hands.mode)
+			}
+		})
+	}
+}
+
+func TestPlayer_DoubleDownFor(t *testing.T) {
+	tests := []struct {
+		name       string
+		wallet     Money
+		amount     Money
+		wantWallet Money
+		wantBet    Money
+		wantErr    error
+	}{
+		{
+			name:       "double for less",
+			wallet:     50,
+			amount:     50,
+			wantWallet: 0,
+			wantBet:    150,
+		},
+		{
+			name:       "double for the full bet",
+			wallet:     500,
+			amount:     100,
+			wantWallet: 400,
+			wantBet:    200,
+		},
+		{
+			name:       "not more than the original bet",
+			wallet:     500,
+			amount:     101,
+			wantWallet: 500,
+			wantBet:    100,
+			wantErr:    ErrNotAllowed,
+		},
+		{
+			name:       "not more than the wallet",
+			wallet:     50,
+			amount:     60,
+			wantWallet: 50,
+			wantBet:    100,
+			wantErr:    ErrNotAllowed,
+		},
+		{
+			name:       "not for nothing",
+			wallet:     50,
+			amount:     0,
+			wantWallet: 50,
+			wantBet:    100,
+			wantErr:    ErrNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player := &Player{
+				wallet: NewMemoryWallet(tt.wallet),
+				hands: newHands(withBet(100), func(h *hand) *hand {
+					h.cards = []deck.Card{
+						{Rank: deck.Nine, Suit: deck.Club},
+						{Rank: deck.Two, Suit: deck.Heart},
+					}
+					return h
+				}),
+			}
+
+			err := player.DoubleDownFor(deck.Card{Rank: deck.Eight, Suit: deck.Heart}, tt.amount)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("want err %#v, got %#v", tt.wantErr, err)
+			}
+
+			if player.wallet.Balance() != tt.wantWallet {
+				t.Errorf("want wallet %d, got %d", tt.wantWallet, player.wallet.Balance())
+			}
+
+			if player.hands.active.bet != tt.wantBet {
+				t.Errorf("want bet %d, got %d", tt.wantBet, player.hands.active.bet)
+			}
+		})
+	}
+}
+
+type failingWallet struct {
+	MemoryWallet
+}
+
+func (w *failingWallet) Debit(Transaction) error {
+	return errors.New("account locked")
+}
+
+func TestWithWallet(t *testing.T) {
+	wallet := NewMemoryWallet(1000)
+	p := NewPlayer(0, WithWallet(wallet))
+
+	if p.wallet != wallet {
+		t.Errorf("want %#v, got %#v", wallet, p.wallet)
+	}
+
+	if p.Balance() != 1000 {
+		t.Errorf("want balance %d, got %d", 1000, p.Balance())
+	}
+}
+
+func TestPlayer_DebitFailure(t *testing.T) {
+	p := NewPlayer(0, WithWallet(&failingWallet{MemoryWallet: MemoryWallet{balance: 1000}}))
+	p.hands = newHands(withBet(100), func(h *hand) *hand {
+		h.cards = []deck.Card{
+			{Rank: deck.Eight, Suit: deck.Club},
+			{Rank: deck.Eight, Suit: deck.Heart},
+		}
+		return h
+	})
+
+	if err := p.Split(); err == nil {
+		t.Errorf("want err, got nil")
+	}
+
+	if p.hands.mode != normal {
+		t.Errorf("player should not have split")
+	}
+
+	if err := p.DoubleDown(deck.Card{Rank: deck.Two, Suit: deck.Club}); err == nil {
+		t.Errorf("want err, got nil")
+	}
+
+	if len(p.hands.active.cards) != 2 || p.hands.active.bet != 100 {
+		t.Errorf("hand should not have changed")
+	}
+}
+
+func TestPlayer_Surrender(t *testing.T) {
+	t.Run("surrender the first two cards", func(t *testing.T) {
+		p := NewPlayer(0)
+		p.hands.first.cards = []deck.Card{
+			{Rank: deck.Ten, Suit: deck.Spade},
+			{Rank: deck.Six, Suit: deck.Heart},
+		}
+
+		if err := p.Surrender(); err != nil {
+			t.Errorf("want nil, got %v", err)
+		}
+
+		if !p.hands.first.surrendered {
+			t.Errorf("hand should be surrendered")
+		}
+
+		if !p.isDone() {
+			t.Errorf("player should be done")
+		}
+	})
+
+	t.Run("not surrender after hitting", func(t *testing.T) {
+		p := NewPlayer(0)
+		p.hands.first.cards = []deck.Card{
+			{Rank: deck.Ten, Suit: deck.Spade},
+			{Rank: deck.Two, Suit: deck.Heart},
+			{Rank: deck.Three, Suit: deck.Heart},
+		}
+
+		if err := p.Surrender(); !errors.Is(err, ErrNotAllowed) {
+			t.Errorf("want %#v, got %#v", ErrNotAllowed, err)
+		}
+	})
+
+	t.Run("not surrender a split hand", func(t *testing.T) {
+		p := NewPlayer(0)
+		p.hands = newSplitHands(
+			deck.Card{Rank: deck.Eight, Suit: deck.Spade},
+			deck.Card{Rank: deck.Eight, Suit: deck.Heart},
+			100,
+		)
+		p.Hit(deck.Card{Rank: deck.Eight, Suit: deck.Club})
+
+		if err := p.Surrender(); !errors.Is(err, ErrNotAllowed) {
+			t.Errorf("want %#v, got %#v", ErrNotAllowed, err)
+		}
+	})
+}
+
+func TestPlayer_Hands(t *testing.T) {
+	eight := deck.Card{Rank: deck.Eight, Suit: deck.Club}
+	two := deck.Card{Rank: deck.Two, Suit: deck.Heart}
+
+	tests := []struct {
+		name       string
+		hands      *hands
+		want       [][]deck.Card
+		wantActive int
+	}{
+		{
+			name:       "one hand",
+			hands:      &hands{first: &hand{cards: []deck.Card{eight, two}}},
+			want:       [][]deck.Card{{eight, two}},
+			wantActive: -1,
+		},
+		{
+			name:       "split hands while playing the second",
+			hands:      &hands{mode: split, first: &hand{cards: []deck.Card{eight, two}}, second: &hand{cards: []deck.Card{eight}}},
+			want:       [][]deck.Card{{eight, two}, {eight}},
+			wantActive: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantActive == 1 {
+				tt.hands.active = tt.hands.second
+			}
+			p := &Player{hands: tt.hands}
+
+			got := p.Hands()
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("want %#v, got %#v", tt.want, got)
+			}
+			if active := p.ActiveHand(); active != tt.wantActive {
+				t.Errorf("want %#v, got %#v", tt.wantActive, active)
+			}
+
+			got[0][0] = two
+			if p.hands.first.cards[0] != eight {
+				t.Errorf("want a copy of the cards, got the hand changed")
 			}
 		})
 	}
@@ -0,0 +1,32 @@
+package blackjack
+
+// PontoonRules returns the British rules of Pontoon. Both cards of the dealer are dealt face down and the dealer wins
+// every tie, a dealer pontoon even beats a pontoon. A pontoon and a five-card trick pay 2 to 1. Players can not
+// stick below 15 and buy cards instead of doubling.
+func PontoonRules() Rules {
+	return Rules{
+		Decks:                  2,
+		DealerHidden:           true,
+		DealerBlackJackWinsAll: true,
+		DealerWinsTies:         true,
+		BlackJackPayout:        Payout{Win: 2, Stake: 1},
+		FiveCardTrick:          true,
+		Buy:                    true,
+		MinStand:               15,
+	}
+}
+
+// NewPontoon creates a pointer to a Table playing PontoonRules. Options are applied on top of the variant.
+func NewPontoon(opts ...func(t *Table) *Table) *Table {
+	return New(append([]func(t *Table) *Table{WithRules(PontoonRules())}, opts...)...)
+}
+
+// Twist is the Pontoon name for Hit.
+func (t *Table) Twist() error {
+	return t.Hit()
+}
+
+// Stick is the Pontoon name for Stand.
+func (t *Table) Stick() error {
+	return t.Stand()
+}
@@ -0,0 +1,166 @@
+package blackjack
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Hydoc/deck"
+)
+
+func TestNewPontoon(t *testing.T) {
+	table := NewPontoon(WithBetLimits(5*Unit, 0))
+
+	if len(table.deck) != 104 {
+		t.Errorf("want %d cards, got %d", 104, len(table.deck))
+	}
+
+	if !table.rules.FiveCardTrick || !table.rules.Buy || table.rules.MinStand != 15 || table.rules.MinBet != 5*Unit {
+		t.Errorf("want Pontoon rules with limits, got %#v", table.rules)
+	}
+}
+
+func TestPontoon_Play(t *testing.T) {
+	tests := []struct {
+		name        string
+		deck        []deck.Card
+		play        func(table *Table) error
+		wantBalance Money
+	}{
+		{
+			name: "five-card trick pays 2 to 1",
+			deck: stack(
+				deck.Card{Rank: deck.Two, Suit: deck.Heart},
+				deck.Card{Rank: deck.King, Suit: deck.Club},
+				deck.Card{Rank: deck.Three, Suit: deck.Club},
+				deck.Card{Rank: deck.Queen, Suit: deck.Club},
+				deck.Card{Rank: deck.Four, Suit: deck.Heart},
+				deck.Card{Rank: deck.Two, Suit: deck.Spade},
+				deck.Card{Rank: deck.Five, Suit: deck.Spade},
+			),
+			play: func(table *Table) error {
+				for range 3 {
+					if err := table.Twist(); err != nil {
+						return err
+					}
+				}
+				if table.InProgress() {
+					return errors.New("five-card trick should stick automatically")
+				}
+				return nil
+			},
+			wantBalance: 120 * Unit,
+		},
+		{
+			name: "dealer wins ties",
+			deck: stack(
+				deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+				deck.Card{Rank: deck.King, Suit: deck.Club},
+				deck.Card{Rank: deck.Nine, Suit: deck.Club},
+				deck.Card{Rank: deck.Nine, Suit: deck.Heart},
+			),
+			play:        func(table *Table) error { return table.Stick() },
+			wantBalance: 90 * Unit,
+		},
+		{
+			name: "can not stick below 15",
+			deck: stack(
+				deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+				deck.Card{Rank: deck.King, Suit: deck.Club},
+				deck.Card{Rank: deck.Four, Suit: deck.Club},
+				deck.Card{Rank: deck.Seven, Suit: deck.Heart},
+				deck.Card{Rank: deck.Five, Suit: deck.Heart},
+			),
+			play: func(table *Table) error {
+				if err := table.Stick(); !errors.Is(err, ErrNotAllowed) {
+					return errors.New("stick on 14 should not be allowed")
+				}
+				if err := table.Twist(); err != nil {
+					return err
+				}
+				return table.Stick()
+			},
+			wantBalance: 110 * Unit,
+		},
+		{
+			name: "buy and twist",
+			deck: stack(
+				deck.Card{Rank: deck.Five, Suit: deck.Heart},
+				deck.Card{Rank: deck.King, Suit: deck.Club},
+				deck.Card{Rank: deck.Four, Suit: deck.Club},
+				deck.Card{Rank: deck.Seven, Suit: deck.Heart},
+				deck.Card{Rank: deck.Three, Suit: deck.Heart},
+				deck.Card{Rank: deck.Six, Suit: deck.Heart},
+			),
+			play: func(table *Table) error {
+				if err := table.DoubleDown(); !errors.Is(err, ErrNotAllowed) {
+					return errors.New("double down should not be allowed")
+				}
+				if err := table.Buy(20 * Unit); !errors.Is(err, ErrNotAllowed) {
+					return errors.New("buy for more than the bet should not be allowed")
+				}
+				if err := table.Buy(10 * Unit); err != nil {
+					return err
+				}
+				if err := table.Twist(); err != nil {
+					return err
+				}
+				if err := table.Buy(10 * Unit); !errors.Is(err, ErrNotAllowed) {
+					return errors.New("buy after twist should not be allowed")
+				}
+				return table.Stick()
+			},
+			wantBalance: 120 * Unit,
+		},
+		{
+			name: "dealer pontoon beats pontoon",
+			deck: stack(
+				deck.Card{Rank: deck.Ace, Suit: deck.Heart},
+				deck.Card{Rank: deck.Ace, Suit: deck.Club},
+				deck.Card{Rank: deck.King, Suit: deck.Club},
+				deck.Card{Rank: deck.Queen, Suit: deck.Heart},
+			),
+			play:        func(table *Table) error { return nil },
+			wantBalance: 90 * Unit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewPontoon()
+			table.deck = tt.deck
+			player := NewPlayer(100 * Unit)
+			table.Join(player)
+			table.Bet(player, 10*Unit)
+
+			if err := table.Start(); err != nil {
+				t.Fatalf("want nil, got %v", err)
+			}
+
+			if err := tt.play(table); err != nil {
+				t.Fatalf("want nil, got %v", err)
+			}
+
+			if got := player.Balance(); got != tt.wantBalance {
+				t.Errorf("want %s, got %s", tt.wantBalance, got)
+			}
+		})
+	}
+}
+
+func TestPontoon_StateDealerCards(t *testing.T) {
+	table := NewPontoon()
+	table.deck = stack(
+		deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+		deck.Card{Rank: deck.King, Suit: deck.Club},
+		deck.Card{Rank: deck.Six, Suit: deck.Club},
+		deck.Card{Rank: deck.Nine, Suit: deck.Heart},
+	)
+	player := NewPlayer(100 * Unit)
+	table.Join(player)
+	table.Bet(player, 10*Unit)
+	table.Start()
+
+	if got := table.State().DealerCards; len(got) != 0 {
+		t.Errorf("want no dealer cards, got %#v", got)
+	}
+}
@@ -0,0 +1,204 @@
+package protocol
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Hydoc/blackjack"
+)
+
+var (
+	ErrTimeout      = errors.New("bot did not answer in time")
+	ErrClosed       = errors.New("bot closed its output")
+	ErrInvalidReply = errors.New("invalid reply")
+)
+
+// Process is a bot speaking the protocol. It implements blackjack.Bot, so it can be bound to a seat:
+//
+//	bot, err := protocol.Start(time.Second, "python3", "bot.py")
+//	...
+//	table.Bind(player, bot)
+//
+// Once the bot fails to read a message or answer in time or answers something else than an action it is not
+// asked again, every following Action is to stand. Err returns why.
+type Process struct {
+	w       io.Writer
+	lines   <-chan string
+	done    chan struct{}
+	timeout time.Duration
+	cmd     *exec.Cmd
+	err     error
+
+	// writing serializes the writes to the bot, a write which timed out may still hold it.
+	writing sync.Mutex
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// New greets the bot reading from r and writing to w and waits for it to be ready.
+func New(r io.Reader, w io.Writer, timeout time.Duration) (*Process, error) {
+	lines := make(chan string)
+	done := make(chan struct{})
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	p := &Process{w: w, lines: lines, done: done, timeout: timeout}
+	if err := p.greet(); err != nil {
+		close(done)
+		return nil, err
+	}
+	return p, nil
+}
+
+// greet sends the greeting and waits for the bot to be ready.
+func (p *Process) greet() error {
+	if err := p.send(fmt.Sprintf("blackjack %d\n", Version)); err != nil {
+		return err
+	}
+	reply, err := p.receive()
+	if err != nil {
+		return err
+	}
+	if reply != "ready" {
+		return fmt.Errorf("%w: %q", ErrInvalidReply, reply)
+	}
+	return nil
+}
+
+// Start runs the program with the arguments and greets it. The program is killed if it is not ready in time.
+func Start(timeout time.Duration, name string, args ...string) (*Process, error) {
+	cmd := exec.Command(name, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	p, err := New(stdout, stdin, timeout)
+	if err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return nil, err
+	}
+	p.cmd = cmd
+	return p, nil
+}
+
+// Action asks the bot for one of the legal actions of the turn player.
+func (p *Process) Action(state blackjack.State, legal []blackjack.Action) blackjack.Action {
+	if p.err != nil {
+		return blackjack.ActionStand
+	}
+
+	if p.err = p.send(Message(state, legal)); p.err != nil {
+		return blackjack.ActionStand
+	}
+
+	reply, err := p.receive()
+	if err != nil {
+		p.err = err
+		return blackjack.ActionStand
+	}
+
+	action, ok := ParseAction(reply)
+	if !ok {
+		p.err = fmt.Errorf("%w: %q", ErrInvalidReply, reply)
+		return blackjack.ActionStand
+	}
+	return action
+}
+
+// Err returns why the bot is not asked anymore, nil while it plays.
+func (p *Process) Err() error {
+	return p.err
+}
+
+// Close sends quit and waits for a started program to exit. It is killed if it does not read quit and exit in time.
+// Closing the bot again returns the same error as the first time.
+func (p *Process) Close() error {
+	p.closeOnce.Do(func() {
+		p.closeErr = p.close()
+	})
+	return p.closeErr
+}
+
+func (p *Process) close() error {
+	kill := time.After(p.timeout)
+	p.send("quit\n")
+	close(p.done)
+	if p.cmd == nil {
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- p.cmd.Wait() }()
+	select {
+	case err := <-done:
+		return err
+	case <-kill:
+		p.cmd.Process.Kill()
+		<-done
+		return ErrTimeout
+	}
+}
+
+// send writes the message to the bot. It returns ErrTimeout if the bot does not read it in time, the write is
+// left to finish in the background.
+func (p *Process) send(message string) error {
+	written := make(chan error, 1)
+	go func() {
+		p.writing.Lock()
+		defer p.writing.Unlock()
+		_, err := io.WriteString(p.w, message)
+		written <- err
+	}()
+
+	select {
+	case err := <-written:
+		return err
+	case <-time.After(p.timeout):
+		return ErrTimeout
+	}
+}
+
+// receive returns the next line of the bot which is not empty nor a comment.
+func (p *Process) receive() (string, error) {
+	timeout := time.After(p.timeout)
+	for {
+		select {
+		case line, ok := <-p.lines:
+			if !ok {
+				return "", ErrClosed
+			}
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			return line, nil
+		case <-timeout:
+			return "", ErrTimeout
+		}
+	}
+}
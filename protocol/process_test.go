@@ -0,0 +1,222 @@
+package protocol
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Hydoc/blackjack"
+)
+
+// TestMain lets the test binary play a bot which always stands, so Start can run it as a child process.
+func TestMain(m *testing.M) {
+	if os.Getenv("BLACKJACK_TEST_BOT") == "1" {
+		serve(os.Stdin, os.Stdout, func(string) string { return "stand" })
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// serve answers the greeting and every go with the reply to the message before it until quit.
+func serve(r io.Reader, w io.Writer, reply func(message string) string) {
+	scanner := bufio.NewScanner(r)
+	var message strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "blackjack "):
+			fmt.Fprintln(w, "# hello")
+			fmt.Fprintln(w, "ready")
+		case line == "go":
+			fmt.Fprintln(w, reply(message.String()))
+			message.Reset()
+		case line == "quit":
+			return
+		default:
+			message.WriteString(line + "\n")
+		}
+	}
+}
+
+// pipeBot connects a Process to a bot replying in the same process.
+func pipeBot(t *testing.T, reply func(message string) string) (*Process, error) {
+	t.Helper()
+
+	toBot, fromTable := io.Pipe()
+	toTable, fromBot := io.Pipe()
+	go func() {
+		serve(toBot, fromBot, reply)
+		fromBot.Close()
+	}()
+	t.Cleanup(func() {
+		fromTable.Close()
+		toTable.Close()
+	})
+
+	return New(toTable, fromTable, 100*time.Millisecond)
+}
+
+func TestProcess_Action(t *testing.T) {
+	var messages []string
+	bot, err := pipeBot(t, func(message string) string {
+		messages = append(messages, message)
+		return "hit"
+	})
+	if err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	state := blackjack.State{Round: 3, TurnPlayer: blackjack.NewPlayer(10 * blackjack.Unit)}
+	got := bot.Action(state, []blackjack.Action{blackjack.ActionHit, blackjack.ActionStand})
+	if got != blackjack.ActionHit {
+		t.Errorf("want %s, got %s", blackjack.ActionHit, got)
+	}
+	if bot.Err() != nil {
+		t.Errorf("want nil, got %v", bot.Err())
+	}
+
+	want := []string{"round 3\ndealer\nhand\nbalance 10.00\nlegal hit stand\n"}
+	if len(messages) != 1 || messages[0] != want[0] {
+		t.Errorf("want %#v, got %#v", want, messages)
+	}
+}
+
+func TestProcess_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply func(string) string
+		want  error
+	}{
+		{name: "invalid reply", reply: func(string) string { return "fold" }, want: ErrInvalidReply},
+		{
+			name: "timeout",
+			reply: func(string) string {
+				time.Sleep(300 * time.Millisecond)
+				return "hit"
+			},
+			want: ErrTimeout,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bot, err := pipeBot(t, test.reply)
+			if err != nil {
+				t.Fatalf("want nil, got %v", err)
+			}
+
+			state := blackjack.State{TurnPlayer: blackjack.NewPlayer(10 * blackjack.Unit)}
+			legal := []blackjack.Action{blackjack.ActionHit, blackjack.ActionStand}
+			if got := bot.Action(state, legal); got != blackjack.ActionStand {
+				t.Errorf("want %s, got %s", blackjack.ActionStand, got)
+			}
+			if !errors.Is(bot.Err(), test.want) {
+				t.Errorf("want %v, got %v", test.want, bot.Err())
+			}
+
+			// a failed bot is not asked again
+			if got := bot.Action(state, legal); got != blackjack.ActionStand {
+				t.Errorf("want %s, got %s", blackjack.ActionStand, got)
+			}
+		})
+	}
+}
+
+func TestProcess_NotReading(t *testing.T) {
+	toBot, fromTable := io.Pipe()
+	toTable, fromBot := io.Pipe()
+	go func() {
+		// the bot answers the greeting and never reads again
+		bufio.NewReader(toBot).ReadString('\n')
+		fmt.Fprintln(fromBot, "ready")
+	}()
+	t.Cleanup(func() {
+		fromTable.Close()
+		toTable.Close()
+	})
+
+	bot, err := New(toTable, fromTable, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	var got blackjack.Action
+	done := make(chan struct{})
+	go func() {
+		state := blackjack.State{TurnPlayer: blackjack.NewPlayer(10 * blackjack.Unit)}
+		got = bot.Action(state, []blackjack.Action{blackjack.ActionHit, blackjack.ActionStand})
+		bot.Close()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("want Action and Close to give up on the bot, they still wait")
+	}
+
+	if got != blackjack.ActionStand {
+		t.Errorf("want %s, got %s", blackjack.ActionStand, got)
+	}
+	if !errors.Is(bot.Err(), ErrTimeout) {
+		t.Errorf("want %v, got %v", ErrTimeout, bot.Err())
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   error
+	}{
+		{name: "closed", output: "", want: ErrClosed},
+		{name: "not ready", output: "hello\n", want: ErrInvalidReply},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := New(strings.NewReader(test.output), io.Discard, 100*time.Millisecond)
+			if !errors.Is(err, test.want) {
+				t.Errorf("want %v, got %v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestStart(t *testing.T) {
+	t.Setenv("BLACKJACK_TEST_BOT", "1")
+	bot, err := Start(5*time.Second, os.Args[0], "-test.run=^$")
+	if err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	table := blackjack.New()
+	player := blackjack.NewPlayer(100 * blackjack.Unit)
+	table.Join(player)
+	table.Bind(player, bot)
+
+	for range 10 {
+		table.Bet(player, 10*blackjack.Unit)
+		if err := table.Start(); err != nil {
+			t.Fatalf("want nil, got %v", err)
+		}
+		if !table.IsDone() {
+			t.Fatalf("want the bot to finish the round")
+		}
+	}
+
+	if bot.Err() != nil {
+		t.Errorf("want nil, got %v", bot.Err())
+	}
+	if err := bot.Close(); err != nil {
+		t.Errorf("want nil, got %v", err)
+	}
+	if err := bot.Close(); err != nil {
+		t.Errorf("want closing again to be nil, got %v", err)
+	}
+}
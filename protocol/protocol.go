@@ -0,0 +1,101 @@
+// Package protocol plugs external programs into the seats of a blackjack.Table with a line protocol over their
+// standard input and output, much like UCI does for chess engines. Bots can be written in any language.
+//
+// Every message is a line. The table greets the bot when it starts and the bot answers within the timeout:
+//
+//	> blackjack 1
+//	< ready
+//
+// Whenever the bot has to act, the table describes the round, the dealer's visible cards, the active hand, the
+// balance and the legal actions and finishes with go. The bot answers with one of the legal actions:
+//
+//	> round 12
+//	> dealer 7S
+//	> hand TH 2C
+//	> balance 90.00
+//	> legal hit stand double
+//	> go
+//	< hit
+//
+// When the session is over the table sends quit and the bot exits. Cards are written as rank and suit, the ranks are
+// A, 2 to 9, T, J, Q and K and the suits S, C, D and H. The actions are hit, stand, double, split and surrender.
+// Bots ignore lines they do not know, the table ignores empty lines of the bot and lines starting with #, which
+// bots can use for logging.
+package protocol
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/deck"
+)
+
+// Version is the version of the protocol sent in the greeting.
+const Version = 1
+
+var actionNames = map[blackjack.Action]string{
+	blackjack.ActionHit:        "hit",
+	blackjack.ActionStand:      "stand",
+	blackjack.ActionDoubleDown: "double",
+	blackjack.ActionSplit:      "split",
+	blackjack.ActionSurrender:  "surrender",
+}
+
+// ActionName returns the name of the action in the protocol.
+func ActionName(a blackjack.Action) string {
+	return actionNames[a]
+}
+
+// ParseAction returns the action of the name in the protocol.
+func ParseAction(name string) (blackjack.Action, bool) {
+	for a, n := range actionNames {
+		if n == name {
+			return a, true
+		}
+	}
+	return 0, false
+}
+
+// Card returns the card in the protocol, for example TH for the ten of hearts.
+func Card(c deck.Card) string {
+	return string("?A23456789TJQK"[c.Rank]) + string("SCDH"[c.Suit])
+}
+
+// ParseCard returns the card of the protocol.
+func ParseCard(s string) (deck.Card, bool) {
+	if len(s) != 2 {
+		return deck.Card{}, false
+	}
+	rank := strings.IndexByte("?A23456789TJQK", s[0])
+	suit := strings.IndexByte("SCDH", s[1])
+	if rank < 1 || suit < 0 {
+		return deck.Card{}, false
+	}
+	return deck.Card{Rank: deck.Rank(rank), Suit: deck.Suit(suit)}, true
+}
+
+// Message returns the lines asking the turn player of the state for one of the legal actions.
+func Message(state blackjack.State, legal []blackjack.Action) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "round %d\n", state.Round)
+	b.WriteString("dealer")
+	for _, c := range state.DealerCards {
+		b.WriteString(" " + Card(c))
+	}
+	b.WriteString("\nhand")
+	if state.TurnPlayer != nil {
+		for _, c := range state.TurnPlayer.Cards() {
+			b.WriteString(" " + Card(c))
+		}
+		fmt.Fprintf(&b, "\nbalance %s", state.TurnPlayer.Balance())
+	}
+	b.WriteString("\nlegal")
+	for _, a := range legal {
+		b.WriteString(" " + ActionName(a))
+	}
+	b.WriteString("\ngo\n")
+
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package protocol
+
+import (
+	"testing"
+
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/deck"
+)
+
+func TestCard(t *testing.T) {
+	tests := []struct {
+		card deck.Card
+		want string
+	}{
+		{card: deck.Card{Rank: deck.Ace, Suit: deck.Spade}, want: "AS"},
+		{card: deck.Card{Rank: deck.Ten, Suit: deck.Heart}, want: "TH"},
+		{card: deck.Card{Rank: deck.Seven, Suit: deck.Club}, want: "7C"},
+		{card: deck.Card{Rank: deck.King, Suit: deck.Diamond}, want: "KD"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			if got := Card(test.card); got != test.want {
+				t.Errorf("want %#v, got %#v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestParseCard(t *testing.T) {
+	for _, suit := range deck.Suits {
+		for rank := deck.Ace; rank <= deck.King; rank++ {
+			card := deck.Card{Rank: rank, Suit: suit}
+			got, ok := ParseCard(Card(card))
+			if !ok || got != card {
+				t.Errorf("want %#v, got %#v", card, got)
+			}
+		}
+	}
+
+	for _, s := range []string{"", "A", "1S", "AX", "10H"} {
+		if _, ok := ParseCard(s); ok {
+			t.Errorf("want %q to be invalid", s)
+		}
+	}
+}
+
+func TestParseAction(t *testing.T) {
+	for _, a := range []blackjack.Action{
+		blackjack.ActionHit,
+		blackjack.ActionStand,
+		blackjack.ActionDoubleDown,
+		blackjack.ActionSplit,
+		blackjack.ActionSurrender,
+	} {
+		got, ok := ParseAction(ActionName(a))
+		if !ok || got != a {
+			t.Errorf("want %s, got %s", a, got)
+		}
+	}
+
+	if _, ok := ParseAction("double down"); ok {
+		t.Errorf("want double down to be invalid")
+	}
+}
+
+func TestMessage(t *testing.T) {
+	player := blackjack.NewPlayer(90 * blackjack.Unit)
+	state := blackjack.State{
+		Round:       12,
+		DealerCards: []deck.Card{{Rank: deck.Seven, Suit: deck.Spade}},
+		TurnPlayer:  player,
+	}
+
+	got := Message(state, []blackjack.Action{blackjack.ActionHit, blackjack.ActionStand, blackjack.ActionDoubleDown})
+
+	want := "round 12\ndealer 7S\nhand\nbalance 90.00\nlegal hit stand double\ngo\n"
+	if got != want {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+}
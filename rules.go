@@ -0,0 +1,170 @@
+package blackjack
+
+import (
+	"errors"
+	"math/rand/v2"
+
+	"github.com/Hydoc/deck"
+)
+
+var (
+	ErrBetBelowMinimum = errors.New("bet below table minimum")
+	ErrBetAboveMaximum = errors.New("bet above table maximum")
+)
+
+// DoubleRule decides on which hands a player may double down.
+type DoubleRule int
+
+const (
+	// DoubleNineToEleven allows doubling on a total of 9, 10 or 11 with the first two cards.
+	DoubleNineToEleven DoubleRule = iota
+	// DoubleTenToEleven allows doubling on a total of 10 or 11 with the first two cards.
+	DoubleTenToEleven
+	// DoubleAnyTwo allows doubling on any first two cards.
+	DoubleAnyTwo
+	// DoubleAny allows doubling on any amount of cards.
+	DoubleAny
+)
+
+// Rules holds the house rules a Table is played with.
+// Start with DefaultRules to change single rules of the default table.
+type Rules struct {
+	// Decks is the amount of decks in the shoe. Zero uses 6 decks.
+	Decks int
+	// StripTens removes the tens, but not the face cards, from every deck in the shoe.
+	StripTens bool
+	// MinBet is the smallest wager allowed. Zero means there is no minimum.
+	MinBet Money
+	// MaxBet is the highest wager allowed. Zero means there is no maximum.
+	MaxBet Money
+	// DealerHitsSoft17 lets the dealer draw on a soft 17 instead of standing.
+	DealerHitsSoft17 bool
+	// Peek lets the dealer check for a black jack right after dealing. With a dealer black jack the round ends
+	// before anyone plays, so only the original bets are lost.
+	Peek bool
+	// DoubleOn decides on which hands doubling down is allowed.
+	DoubleOn DoubleRule
+	// DoubleForLess allows doubling down for any amount up to the original bet.
+	DoubleForLess bool
+	// DoubleAfterSplit allows doubling down on split hands.
+	DoubleAfterSplit bool
+	// Redouble is how often a doubled hand may be doubled again. The hand keeps being played until it
+	// is not doubled again.
+	Redouble int
+	// Surrender allows late surrender, giving up the first two cards for half the bet.
+	Surrender bool
+	// BlackJackPayout is what a black jack pays. The zero value pays 3 to 2.
+	BlackJackPayout Payout
+	// Rounding is applied when a payout is not a whole minor unit. The zero value rounds down.
+	Rounding Rounding
+	// Switch deals every player two hands with equal bets and allows swapping their second cards.
+	Switch bool
+	// DealerPushesOn22 makes a dealer total of 22 push against every hand which is not busted,
+	// except for a black jack.
+	DealerPushesOn22 bool
+	// FreeDoubles lets the house fund every double on the first two cards of a hard 9, 10 or 11 with a free bet.
+	FreeDoubles bool
+	// FreeSplits lets the house fund the second hand of every split pair except for tens with a free bet.
+	FreeSplits bool
+	// DealerHidden deals both cards of the dealer face down, there is no up card.
+	DealerHidden bool
+	// DealerBlackJackWinsAll lets a dealer black jack beat every hand, even a black jack.
+	DealerBlackJackWinsAll bool
+	// FiveCardTrick pays 2 to 1 for a hand of five or more cards which did not bust, unless the dealer has a black jack.
+	// The hand stands automatically after the fifth card.
+	FiveCardTrick bool
+	// Buy replaces doubling. A hand can buy cards for up to its original bet and keep playing afterwards.
+	Buy bool
+	// Charlie is the number of cards, usually 5, 6 or 7, which win automatically without busting, no matter what the
+	// dealer has. The hand stands automatically when it is reached. Zero disables the rule.
+	Charlie int
+	// MinStand is the lowest total a player may stand on. Zero allows standing on any total.
+	MinStand int
+	// DealerExposed deals both cards of the dealer face up.
+	DealerExposed bool
+	// DealerWinsTies lets the dealer win every tie, except for a tied black jack which the player wins.
+	DealerWinsTies bool
+	// PlayerTwentyOneWins lets a player's 21 always win, no matter what the dealer has.
+	PlayerTwentyOneWins bool
+	// TwentyOneBonuses pays a bonus for a 21 made of five or more cards and for 6-7-8 and 7-7-7
+	// on hands which were not doubled.
+	TwentyOneBonuses bool
+	// Chips are the chip denominations available at the table. Every wager must be made of them.
+	// No chips means any amount can be bet.
+	Chips []Money
+}
+
+// DefaultRules returns the rules New plays with.
+func DefaultRules() Rules {
+	return Rules{
+		Decks:            6,
+		DoubleAfterSplit: true,
+	}
+}
+
+// decks returns the amount of decks in the shoe, 6 if none are set.
+func (r Rules) decks() int {
+	if r.Decks <= 0 {
+		return 6
+	}
+	return r.Decks
+}
+
+// shoe builds a shoe for the rules shuffled with the source of randomness, or the global one if it is nil.
+// The cards are shuffled in place, deck.Shuffle loses cards of the shoe.
+func (r Rules) shoe(rnd *rand.Rand) []deck.Card {
+	opts := []func([]deck.Card) []deck.Card{}
+	if r.StripTens {
+		opts = append(opts, deck.Filter(func(card deck.Card) bool { return card.Rank != deck.Ten }))
+	}
+	opts = append(opts, deck.WithDecks(r.decks()))
+
+	cards := deck.New(opts...)
+	swap := func(i, j int) { cards[i], cards[j] = cards[j], cards[i] }
+	if rnd == nil {
+		rand.Shuffle(len(cards), swap)
+	} else {
+		rnd.Shuffle(len(cards), swap)
+	}
+	return cards
+}
+
+// isCharlie reports whether the hand won with a Charlie.
+func (r Rules) isCharlie(h *hand) bool {
+	return r.Charlie > 0 && len(h.cards) >= r.Charlie && !h.busted()
+}
+
+// faceUp returns how many of the dealer's cards the players see while the round is in progress.
+func (r Rules) faceUp() int {
+	switch {
+	case r.DealerExposed:
+		return 2
+	case r.DealerHidden:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// blackJackPayout returns the configured black jack payout, 3 to 2 if none is set.
+func (r Rules) blackJackPayout() Payout {
+	if r.BlackJackPayout.Stake == 0 {
+		return ThreeToTwo
+	}
+	return r.BlackJackPayout
+}
+
+// checkBet returns ErrBetBelowMinimum or ErrBetAboveMaximum if the amount is outside the table limits
+// and ErrInvalidDenomination if it can not be made with the table's chips.
+func (r Rules) checkBet(amount Money) error {
+	if amount < r.MinBet {
+		return ErrBetBelowMinimum
+	}
+	if r.MaxBet > 0 && amount > r.MaxBet {
+		return ErrBetAboveMaximum
+	}
+	if len(r.Chips) > 0 && !composable(amount, r.Chips) {
+		return ErrInvalidDenomination
+	}
+	return nil
+}
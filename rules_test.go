@@ -0,0 +1,118 @@
+package blackjack
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Hydoc/deck"
+)
+
+func TestRules_checkBet(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   Rules
+		amount  Money
+		wantErr error
+	}{
+		{
+			name:   "no limits",
+			rules:  Rules{},
+			amount: 1_000_000,
+		},
+		{
+			name:   "exactly the minimum",
+			rules:  Rules{MinBet: 5, MaxBet: 500},
+			amount: 5,
+		},
+		{
+			name:   "exactly the maximum",
+			rules:  Rules{MinBet: 5, MaxBet: 500},
+			amount: 500,
+		},
+		{
+			name:    "below the minimum",
+			rules:   Rules{MinBet: 5, MaxBet: 500},
+			amount:  4,
+			wantErr: ErrBetBelowMinimum,
+		},
+		{
+			name:    "above the maximum",
+			rules:   Rules{MinBet: 5, MaxBet: 500},
+			amount:  501,
+			wantErr: ErrBetAboveMaximum,
+		},
+		{
+			name:   "no maximum",
+			rules:  Rules{MinBet: 500},
+			amount: 100_000,
+		},
+		{
+			name:   "made of the table's chips",
+			rules:  Rules{MinBet: 500, Chips: []Money{100, 500, 2500}},
+			amount: 3600,
+		},
+		{
+			name:    "not made of the table's chips",
+			rules:   Rules{MinBet: 500, Chips: []Money{500, 2500}},
+			amount:  750,
+			wantErr: ErrInvalidDenomination,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rules.checkBet(tt.amount); !errors.Is(err, tt.wantErr) {
+				t.Errorf("want %#v, got %#v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRules_blackJackPayout(t *testing.T) {
+	if got := (Rules{}).blackJackPayout(); got != ThreeToTwo {
+		t.Errorf("want %s, got %s", ThreeToTwo, got)
+	}
+
+	if got := (Rules{BlackJackPayout: SixToFive}).blackJackPayout(); got != SixToFive {
+		t.Errorf("want %s, got %s", SixToFive, got)
+	}
+}
+
+func TestRules_shoe(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    Rules
+		wantSize int
+		wantTens bool
+	}{
+		{name: "default decks", rules: Rules{}, wantSize: 312, wantTens: true},
+		{name: "single deck", rules: Rules{Decks: 1}, wantSize: 52, wantTens: true},
+		{name: "stripped tens", rules: Rules{Decks: 6, StripTens: true}, wantSize: 288},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shoe := tt.rules.shoe(nil)
+
+			if len(shoe) != tt.wantSize {
+				t.Errorf("want %d cards, got %d", tt.wantSize, len(shoe))
+			}
+
+			counts := make(map[deck.Card]int)
+			for _, c := range shoe {
+				counts[c]++
+			}
+			for c, n := range counts {
+				if n != tt.rules.decks() {
+					t.Errorf("want %d of %s, got %d", tt.rules.decks(), c, n)
+				}
+			}
+
+			hasTens := slices.ContainsFunc(shoe, func(c deck.Card) bool { return c.Rank == deck.Ten })
+			if hasTens != tt.wantTens {
+				t.Errorf("want tens %v, got %v", tt.wantTens, hasTens)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package blackjack
+
+import "github.com/Hydoc/deck"
+
+// Score is the evaluation of a set of cards.
+type Score struct {
+	// Total is the best total of the cards, counting one ace as 11 if that does not bust the hand.
+	Total int
+	// Soft is true when an ace is counted as 11.
+	Soft bool
+	// BlackJack is true for an ace and a ten valued card as the only two cards.
+	BlackJack bool
+	// Busted is true when the total is above 21.
+	Busted bool
+}
+
+// Evaluate scores the given cards. Every ace counts as 1, except for one ace which counts as 11
+// if the total stays at or below 21. In that case the score is soft.
+func Evaluate(cards []deck.Card) Score {
+	total := 0
+	hasAce := false
+
+	for _, card := range cards {
+		total += value(card)
+		if card.Rank == deck.Ace {
+			hasAce = true
+		}
+	}
+
+	soft := false
+	if hasAce && total+10 <= 21 {
+		total += 10
+		soft = true
+	}
+
+	return Score{
+		Total:     total,
+		Soft:      soft,
+		BlackJack: len(cards) == 2 && total == 21,
+		Busted:    total > 21,
+	}
+}
+
+// value returns the hard value of a card, an ace counts as 1 and face cards count as 10.
+func value(card deck.Card) int {
+	if card.Rank >= deck.Ten {
+		return 10
+	}
+	return int(card.Rank)
+}
@@ -0,0 +1,207 @@
+package blackjack
+
+import (
+	"testing"
+
+	"github.com/Hydoc/deck"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name  string
+		cards []deck.Card
+		want  Score
+	}{
+		{
+			name:  "no cards",
+			cards: []deck.Card{},
+			want:  Score{},
+		},
+		{
+			name: "single ace",
+			cards: []deck.Card{
+				{Rank: deck.Ace, Suit: deck.Spade},
+			},
+			want: Score{Total: 11, Soft: true},
+		},
+		{
+			name: "two and three",
+			cards: []deck.Card{
+				{Rank: deck.Two, Suit: deck.Spade},
+				{Rank: deck.Three, Suit: deck.Heart},
+			},
+			want: Score{Total: 5},
+		},
+		{
+			name: "face cards count as ten",
+			cards: []deck.Card{
+				{Rank: deck.Jack, Suit: deck.Spade},
+				{Rank: deck.Queen, Suit: deck.Heart},
+			},
+			want: Score{Total: 20},
+		},
+		{
+			name: "king and seven",
+			cards: []deck.Card{
+				{Rank: deck.King, Suit: deck.Spade},
+				{Rank: deck.Seven, Suit: deck.Heart},
+			},
+			want: Score{Total: 17},
+		},
+		{
+			name: "ace and six is soft 17",
+			cards: []deck.Card{
+				{Rank: deck.Ace, Suit: deck.Spade},
+				{Rank: deck.Six, Suit: deck.Heart},
+			},
+			want: Score{Total: 17, Soft: true},
+		},
+		{
+			name: "ace and ten is black jack",
+			cards: []deck.Card{
+				{Rank: deck.Ace, Suit: deck.Spade},
+				{Rank: deck.Ten, Suit: deck.Heart},
+			},
+			want: Score{Total: 21, Soft: true, BlackJack: true},
+		},
+		{
+			name: "queen and ace is black jack",
+			cards: []deck.Card{
+				{Rank: deck.Queen, Suit: deck.Spade},
+				{Rank: deck.Ace, Suit: deck.Heart},
+			},
+			want: Score{Total: 21, Soft: true, BlackJack: true},
+		},
+		{
+			name: "two aces",
+			cards: []deck.Card{
+				{Rank: deck.Ace, Suit: deck.Spade},
+				{Rank: deck.Ace, Suit: deck.Heart},
+			},
+			want: Score{Total: 12, Soft: true},
+		},
+		{
+			name: "ace, five and six is hard 12",
+			cards: []deck.Card{
+				{Rank: deck.Ace, Suit: deck.Spade},
+				{Rank: deck.Five, Suit: deck.Heart},
+				{Rank: deck.Six, Suit: deck.Club},
+			},
+			want: Score{Total: 12},
+		},
+		{
+			name: "ace, four and six is soft 21 without black jack",
+			cards: []deck.Card{
+				{Rank: deck.Ace, Suit: deck.Spade},
+				{Rank: deck.Four, Suit: deck.Heart},
+				{Rank: deck.Six, Suit: deck.Club},
+			},
+			want: Score{Total: 21, Soft: true},
+		},
+		{
+			name: "queen, jack and ace is hard 21",
+			cards: []deck.Card{
+				{Rank: deck.Queen, Suit: deck.Spade},
+				{Rank: deck.Jack, Suit: deck.Heart},
+				{Rank: deck.Ace, Suit: deck.Club},
+			},
+			want: Score{Total: 21},
+		},
+		{
+			name: "ten, nine and two is 21 without black jack",
+			cards: []deck.Card{
+				{Rank: deck.Ten, Suit: deck.Spade},
+				{Rank: deck.Nine, Suit: deck.Heart},
+				{Rank: deck.Two, Suit: deck.Heart},
+			},
+			want: Score{Total: 21},
+		},
+		{
+			name: "four aces and seven is soft 21",
+			cards: []deck.Card{
+				{Rank: deck.Ace, Suit: deck.Spade},
+				{Rank: deck.Ace, Suit: deck.Heart},
+				{Rank: deck.Ace, Suit: deck.Club},
+				{Rank: deck.Ace, Suit: deck.Diamond},
+				{Rank: deck.Seven, Suit: deck.Diamond},
+			},
+			want: Score{Total: 21, Soft: true},
+		},
+		{
+			name: "ace, ace and king is hard 12",
+			cards: []deck.Card{
+				{Rank: deck.Ace, Suit: deck.Spade},
+				{Rank: deck.Ace, Suit: deck.Heart},
+				{Rank: deck.King, Suit: deck.Club},
+			},
+			want: Score{Total: 12},
+		},
+		{
+			name: "ace, six and ten is hard 17",
+			cards: []deck.Card{
+				{Rank: deck.Ace, Suit: deck.Spade},
+				{Rank: deck.Six, Suit: deck.Heart},
+				{Rank: deck.Ten, Suit: deck.Club},
+			},
+			want: Score{Total: 17},
+		},
+		{
+			name: "ten, six and queen is busted",
+			cards: []deck.Card{
+				{Rank: deck.Ten, Suit: deck.Spade},
+				{Rank: deck.Six, Suit: deck.Heart},
+				{Rank: deck.Queen, Suit: deck.Club},
+			},
+			want: Score{Total: 26, Busted: true},
+		},
+		{
+			name: "ace, ten, five and seven is busted",
+			cards: []deck.Card{
+				{Rank: deck.Ace, Suit: deck.Spade},
+				{Rank: deck.Ten, Suit: deck.Heart},
+				{Rank: deck.Five, Suit: deck.Club},
+				{Rank: deck.Seven, Suit: deck.Club},
+			},
+			want: Score{Total: 23, Busted: true},
+		},
+		{
+			name: "exactly 22 is busted",
+			cards: []deck.Card{
+				{Rank: deck.King, Suit: deck.Spade},
+				{Rank: deck.Two, Suit: deck.Heart},
+				{Rank: deck.Jack, Suit: deck.Club},
+			},
+			want: Score{Total: 22, Busted: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Evaluate(tt.cards); got != tt.want {
+				t.Errorf("want %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEvaluate_AllTwoCardHands(t *testing.T) {
+	for _, first := range deck.Ranks {
+		for _, second := range deck.Ranks {
+			cards := []deck.Card{
+				{Rank: first, Suit: deck.Spade},
+				{Rank: second, Suit: deck.Heart},
+			}
+			hard := value(cards[0]) + value(cards[1])
+			hasAce := first == deck.Ace || second == deck.Ace
+
+			want := Score{Total: hard}
+			if hasAce {
+				want = Score{Total: hard + 10, Soft: true, BlackJack: hard+10 == 21}
+			}
+
+			if got := Evaluate(cards); got != want {
+				t.Errorf("%s and %s: want %#v, got %#v", first, second, want, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,432 @@
+package blackjack
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Hydoc/deck"
+)
+
+var (
+	ErrUnknownSideBet = errors.New("unknown side bet")
+	ErrNoMainBet      = errors.New("side bet requires a main bet")
+)
+
+// Combination is a winning hand of a side bet, e.g. a flush.
+type Combination string
+
+// Paytable maps every winning Combination of a side bet to what it pays. A Payout without a positive Stake pays
+// Win to 1.
+type Paytable map[Combination]Payout
+
+// normalized returns a copy of the paytable where every Payout without a Stake pays Win to 1.
+func (t Paytable) normalized() Paytable {
+	out := make(Paytable, len(t))
+	for c, p := range t {
+		out[c] = p.normalized()
+	}
+	return out
+}
+
+// SideBetCards are the cards a side bet is evaluated against.
+type SideBetCards struct {
+	// Player are the first two cards of the player.
+	Player []deck.Card
+	// DealerUpCard is the first card of the dealer.
+	DealerUpCard deck.Card
+	// Dealer is the final hand of the dealer. It is only set for side bets settled AfterDealer.
+	Dealer []deck.Card
+}
+
+// Timing tells when a side bet is settled.
+type Timing int
+
+const (
+	// AfterDeal settles the side bet right after the first two cards are dealt.
+	AfterDeal Timing = iota
+	// AfterDealer settles the side bet once the dealer played its hand.
+	AfterDealer
+)
+
+// SideBet is a wager placed besides the main bet. It is settled right after the first two cards are dealt
+// unless it implements TimedSideBet.
+type SideBet interface {
+	// Name identifies the side bet at a Table.
+	Name() string
+	// Validate returns an error if the amount can not be placed next to the main bet.
+	Validate(amount, mainBet Money) error
+	// Evaluate returns the best Combination of the cards and false if the side bet lost.
+	Evaluate(cards SideBetCards) (Combination, bool)
+	// Paytable returns what every Combination pays.
+	Paytable() Paytable
+}
+
+// TimedSideBet is a SideBet which tells when it is settled.
+type TimedSideBet interface {
+	SideBet
+	Timing() Timing
+}
+
+// timing returns when the side bet is settled.
+func timing(bet SideBet) Timing {
+	if timed, ok := bet.(TimedSideBet); ok {
+		return timed.Timing()
+	}
+	return AfterDeal
+}
+
+// placedSideBet is a side bet a player placed for the current round.
+type placedSideBet struct {
+	bet    SideBet
+	amount Money
+}
+
+// validateSideBet is the default validation of the built-in side bets. The amount must be positive
+// and may not exceed the main bet.
+func validateSideBet(amount, mainBet Money) error {
+	if mainBet <= 0 {
+		return ErrNoMainBet
+	}
+	if amount <= 0 {
+		return ErrNotAllowed
+	}
+	if amount > mainBet {
+		return ErrBetAboveMaximum
+	}
+	return nil
+}
+
+const (
+	SuitedTrips   Combination = "suited trips"
+	StraightFlush Combination = "straight flush"
+	ThreeOfAKind  Combination = "three of a kind"
+	Straight      Combination = "straight"
+	Flush         Combination = "flush"
+)
+
+// DefaultTwentyOnePlusThreePaytable is the common paytable for 21+3.
+var DefaultTwentyOnePlusThreePaytable = Paytable{
+	SuitedTrips:   {Win: 100, Stake: 1},
+	StraightFlush: {Win: 40, Stake: 1},
+	ThreeOfAKind:  {Win: 30, Stake: 1},
+	Straight:      {Win: 10, Stake: 1},
+	Flush:         {Win: 5, Stake: 1},
+}
+
+// TwentyOnePlusThree is the 21+3 side bet. It builds a three card poker hand out of the player's first two cards
+// and the dealer's up card.
+type TwentyOnePlusThree struct {
+	paytable Paytable
+}
+
+func (b *TwentyOnePlusThree) Name() string {
+	return "21+3"
+}
+
+func (b *TwentyOnePlusThree) Validate(amount, mainBet Money) error {
+	return validateSideBet(amount, mainBet)
+}
+
+func (b *TwentyOnePlusThree) Evaluate(cards SideBetCards) (Combination, bool) {
+	if len(cards.Player) < 2 {
+		return "", false
+	}
+	hand := []deck.Card{cards.Player[0], cards.Player[1], cards.DealerUpCard}
+
+	suited := hand[0].Suit == hand[1].Suit && hand[1].Suit == hand[2].Suit
+	trips := hand[0].Rank == hand[1].Rank && hand[1].Rank == hand[2].Rank
+	straight := isStraight(hand)
+
+	var combination Combination
+	switch {
+	case trips && suited:
+		combination = SuitedTrips
+	case straight && suited:
+		combination = StraightFlush
+	case trips:
+		combination = ThreeOfAKind
+	case straight:
+		combination = Straight
+	case suited:
+		combination = Flush
+	default:
+		return "", false
+	}
+
+	_, ok := b.paytable[combination]
+	return combination, ok
+}
+
+func (b *TwentyOnePlusThree) Paytable() Paytable {
+	return b.paytable
+}
+
+// isStraight reports whether the three cards have consecutive ranks. An ace can be high or low.
+func isStraight(cards []deck.Card) bool {
+	ranks := []int{int(cards[0].Rank), int(cards[1].Rank), int(cards[2].Rank)}
+	slices.Sort(ranks)
+
+	if ranks[0] == int(deck.Ace) && ranks[1] == int(deck.Queen) && ranks[2] == int(deck.King) {
+		return true
+	}
+	return ranks[1] == ranks[0]+1 && ranks[2] == ranks[1]+1
+}
+
+// NewTwentyOnePlusThree creates the 21+3 side bet with the given paytable,
+// DefaultTwentyOnePlusThreePaytable if it is nil.
+func NewTwentyOnePlusThree(paytable Paytable) *TwentyOnePlusThree {
+	if paytable == nil {
+		paytable = DefaultTwentyOnePlusThreePaytable
+	}
+	return &TwentyOnePlusThree{paytable: paytable.normalized()}
+}
+
+const (
+	PerfectPair Combination = "perfect pair"
+	ColoredPair Combination = "colored pair"
+	MixedPair   Combination = "mixed pair"
+)
+
+// DefaultPerfectPairsPaytable is the common paytable for Perfect Pairs.
+var DefaultPerfectPairsPaytable = Paytable{
+	PerfectPair: {Win: 25, Stake: 1},
+	ColoredPair: {Win: 12, Stake: 1},
+	MixedPair:   {Win: 6, Stake: 1},
+}
+
+// PerfectPairs is the Perfect Pairs side bet. It wins if the player's first two cards are a pair.
+type PerfectPairs struct {
+	paytable Paytable
+}
+
+func (b *PerfectPairs) Name() string {
+	return "Perfect Pairs"
+}
+
+func (b *PerfectPairs) Validate(amount, mainBet Money) error {
+	return validateSideBet(amount, mainBet)
+}
+
+func (b *PerfectPairs) Evaluate(cards SideBetCards) (Combination, bool) {
+	if len(cards.Player) < 2 || cards.Player[0].Rank != cards.Player[1].Rank {
+		return "", false
+	}
+	first, second := cards.Player[0], cards.Player[1]
+
+	var combination Combination
+	switch {
+	case first.Suit == second.Suit:
+		combination = PerfectPair
+	case isRed(first) == isRed(second):
+		combination = ColoredPair
+	default:
+		combination = MixedPair
+	}
+
+	_, ok := b.paytable[combination]
+	return combination, ok
+}
+
+func (b *PerfectPairs) Paytable() Paytable {
+	return b.paytable
+}
+
+func isRed(card deck.Card) bool {
+	return card.Suit == deck.Heart || card.Suit == deck.Diamond
+}
+
+// NewPerfectPairs creates the Perfect Pairs side bet with the given paytable,
+// DefaultPerfectPairsPaytable if it is nil.
+func NewPerfectPairs(paytable Paytable) *PerfectPairs {
+	if paytable == nil {
+		paytable = DefaultPerfectPairsPaytable
+	}
+	return &PerfectPairs{paytable: paytable.normalized()}
+}
+
+// BustWith returns the Combination of Buster Blackjack for a dealer busting with the given amount of cards.
+// Every bust with 8 or more cards is the same Combination.
+func BustWith(cards int) Combination {
+	if cards >= 8 {
+		return "8+ card bust"
+	}
+	return Combination(fmt.Sprintf("%d card bust", cards))
+}
+
+// DefaultBusterBlackjackPaytable is the common paytable for Buster Blackjack.
+var DefaultBusterBlackjackPaytable = Paytable{
+	BustWith(3): {Win: 1, Stake: 1},
+	BustWith(4): {Win: 2, Stake: 1},
+	BustWith(5): {Win: 9, Stake: 1},
+	BustWith(6): {Win: 50, Stake: 1},
+	BustWith(7): {Win: 100, Stake: 1},
+	BustWith(8): {Win: 250, Stake: 1},
+}
+
+// BusterBlackjack is the Buster Blackjack side bet. It wins if the dealer busts and pays by the amount of
+// cards the dealer busted with.
+type BusterBlackjack struct {
+	paytable Paytable
+}
+
+func (b *BusterBlackjack) Name() string {
+	return "Buster Blackjack"
+}
+
+func (b *BusterBlackjack) Validate(amount, mainBet Money) error {
+	return validateSideBet(amount, mainBet)
+}
+
+func (b *BusterBlackjack) Evaluate(cards SideBetCards) (Combination, bool) {
+	if !Evaluate(cards.Dealer).Busted {
+		return "", false
+	}
+
+	combination := BustWith(len(cards.Dealer))
+	_, ok := b.paytable[combination]
+	return combination, ok
+}
+
+func (b *BusterBlackjack) Paytable() Paytable {
+	return b.paytable
+}
+
+func (b *BusterBlackjack) Timing() Timing {
+	return AfterDealer
+}
+
+// NewBusterBlackjack creates the Buster Blackjack side bet with the given paytable,
+// DefaultBusterBlackjackPaytable if it is nil.
+func NewBusterBlackjack(paytable Paytable) *BusterBlackjack {
+	if paytable == nil {
+		paytable = DefaultBusterBlackjackPaytable
+	}
+	return &BusterBlackjack{paytable: paytable.normalized()}
+}
+
+const (
+	QueenOfHeartsWithDealerBlackJack Combination = "queen of hearts pair with dealer black jack"
+	QueenOfHeartsPair                Combination = "queen of hearts pair"
+	MatchedTwenty                    Combination = "matched 20"
+	SuitedTwenty                     Combination = "suited 20"
+	AnyTwenty                        Combination = "any 20"
+)
+
+// DefaultLuckyLadiesPaytable is the common paytable for Lucky Ladies.
+var DefaultLuckyLadiesPaytable = Paytable{
+	QueenOfHeartsWithDealerBlackJack: {Win: 1000, Stake: 1},
+	QueenOfHeartsPair:                {Win: 200, Stake: 1},
+	MatchedTwenty:                    {Win: 25, Stake: 1},
+	SuitedTwenty:                     {Win: 10, Stake: 1},
+	AnyTwenty:                        {Win: 4, Stake: 1},
+}
+
+// LuckyLadies is the Lucky Ladies side bet. It wins if the player's first two cards total 20.
+// The top award needs a pair of queens of hearts and a dealer black jack, so it is settled after the dealer.
+type LuckyLadies struct {
+	paytable Paytable
+}
+
+func (b *LuckyLadies) Name() string {
+	return "Lucky Ladies"
+}
+
+func (b *LuckyLadies) Validate(amount, mainBet Money) error {
+	return validateSideBet(amount, mainBet)
+}
+
+func (b *LuckyLadies) Evaluate(cards SideBetCards) (Combination, bool) {
+	if len(cards.Player) < 2 || Evaluate(cards.Player[:2]).Total != 20 {
+		return "", false
+	}
+	first, second := cards.Player[0], cards.Player[1]
+	queensOfHearts := first.Is(deck.Queen, deck.Heart) && second.Is(deck.Queen, deck.Heart)
+
+	var combination Combination
+	switch {
+	case queensOfHearts && Evaluate(cards.Dealer).BlackJack:
+		combination = QueenOfHeartsWithDealerBlackJack
+	case queensOfHearts:
+		combination = QueenOfHeartsPair
+	case first == second:
+		combination = MatchedTwenty
+	case first.Suit == second.Suit:
+		combination = SuitedTwenty
+	default:
+		combination = AnyTwenty
+	}
+
+	_, ok := b.paytable[combination]
+	return combination, ok
+}
+
+func (b *LuckyLadies) Paytable() Paytable {
+	return b.paytable
+}
+
+func (b *LuckyLadies) Timing() Timing {
+	return AfterDealer
+}
+
+// NewLuckyLadies creates the Lucky Ladies side bet with the given paytable,
+// DefaultLuckyLadiesPaytable if it is nil.
+func NewLuckyLadies(paytable Paytable) *LuckyLadies {
+	if paytable == nil {
+		paytable = DefaultLuckyLadiesPaytable
+	}
+	return &LuckyLadies{paytable: paytable.normalized()}
+}
+
+const (
+	RoyalMatchKingQueen Combination = "royal match"
+	EasyMatch           Combination = "easy match"
+)
+
+// DefaultRoyalMatchPaytable is the common paytable for Royal Match.
+var DefaultRoyalMatchPaytable = Paytable{
+	RoyalMatchKingQueen: {Win: 25, Stake: 1},
+	EasyMatch:           {Win: 5, Stake: 2},
+}
+
+// RoyalMatch is the Royal Match side bet. It wins if the player's first two cards are suited
+// and pays the most for a suited king and queen. It only needs the player's cards and is settled after dealing.
+type RoyalMatch struct {
+	paytable Paytable
+}
+
+func (b *RoyalMatch) Name() string {
+	return "Royal Match"
+}
+
+func (b *RoyalMatch) Validate(amount, mainBet Money) error {
+	return validateSideBet(amount, mainBet)
+}
+
+func (b *RoyalMatch) Evaluate(cards SideBetCards) (Combination, bool) {
+	if len(cards.Player) < 2 || cards.Player[0].Suit != cards.Player[1].Suit {
+		return "", false
+	}
+
+	combination := EasyMatch
+	ranks := []deck.Rank{cards.Player[0].Rank, cards.Player[1].Rank}
+	if slices.Contains(ranks, deck.King) && slices.Contains(ranks, deck.Queen) {
+		combination = RoyalMatchKingQueen
+	}
+
+	_, ok := b.paytable[combination]
+	return combination, ok
+}
+
+func (b *RoyalMatch) Paytable() Paytable {
+	return b.paytable
+}
+
+// NewRoyalMatch creates the Royal Match side bet with the given paytable,
+// DefaultRoyalMatchPaytable if it is nil.
+func NewRoyalMatch(paytable Paytable) *RoyalMatch {
+	if paytable == nil {
+		paytable = DefaultRoyalMatchPaytable
+	}
+	return &RoyalMatch{paytable: paytable.normalized()}
+}
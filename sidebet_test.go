@@ -0,0 +1,315 @@
+package blackjack
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Hydoc/deck"
+)
+
+func TestTwentyOnePlusThree_Evaluate(t *testing.T) {
+	tests := []struct {
+		name     string
+		player   []deck.Card
+		dealer   deck.Card
+		paytable Paytable
+		want     Combination
+		wantOk   bool
+	}{
+		{
+			name:   "suited trips",
+			player: []deck.Card{{Rank: deck.Seven, Suit: deck.Heart}, {Rank: deck.Seven, Suit: deck.Heart}},
+			dealer: deck.Card{Rank: deck.Seven, Suit: deck.Heart},
+			want:   SuitedTrips,
+			wantOk: true,
+		},
+		{
+			name:   "straight flush",
+			player: []deck.Card{{Rank: deck.Nine, Suit: deck.Club}, {Rank: deck.Jack, Suit: deck.Club}},
+			dealer: deck.Card{Rank: deck.Ten, Suit: deck.Club},
+			want:   StraightFlush,
+			wantOk: true,
+		},
+		{
+			name:   "three of a kind",
+			player: []deck.Card{{Rank: deck.Seven, Suit: deck.Heart}, {Rank: deck.Seven, Suit: deck.Club}},
+			dealer: deck.Card{Rank: deck.Seven, Suit: deck.Spade},
+			want:   ThreeOfAKind,
+			wantOk: true,
+		},
+		{
+			name:   "straight with low ace",
+			player: []deck.Card{{Rank: deck.Ace, Suit: deck.Heart}, {Rank: deck.Three, Suit: deck.Club}},
+			dealer: deck.Card{Rank: deck.Two, Suit: deck.Spade},
+			want:   Straight,
+			wantOk: true,
+		},
+		{
+			name:   "straight with high ace",
+			player: []deck.Card{{Rank: deck.King, Suit: deck.Heart}, {Rank: deck.Ace, Suit: deck.Club}},
+			dealer: deck.Card{Rank: deck.Queen, Suit: deck.Spade},
+			want:   Straight,
+			wantOk: true,
+		},
+		{
+			name:   "no straight around the corner",
+			player: []deck.Card{{Rank: deck.King, Suit: deck.Heart}, {Rank: deck.Ace, Suit: deck.Club}},
+			dealer: deck.Card{Rank: deck.Two, Suit: deck.Spade},
+		},
+		{
+			name:   "flush",
+			player: []deck.Card{{Rank: deck.Two, Suit: deck.Diamond}, {Rank: deck.Nine, Suit: deck.Diamond}},
+			dealer: deck.Card{Rank: deck.King, Suit: deck.Diamond},
+			want:   Flush,
+			wantOk: true,
+		},
+		{
+			name:   "nothing",
+			player: []deck.Card{{Rank: deck.Two, Suit: deck.Diamond}, {Rank: deck.Nine, Suit: deck.Heart}},
+			dealer: deck.Card{Rank: deck.King, Suit: deck.Diamond},
+		},
+		{
+			name:     "combination missing in the paytable loses",
+			player:   []deck.Card{{Rank: deck.Two, Suit: deck.Diamond}, {Rank: deck.Nine, Suit: deck.Diamond}},
+			dealer:   deck.Card{Rank: deck.King, Suit: deck.Diamond},
+			paytable: Paytable{Straight: {Win: 10, Stake: 1}},
+			want:     Flush,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bet := NewTwentyOnePlusThree(tt.paytable)
+
+			got, ok := bet.Evaluate(SideBetCards{Player: tt.player, DealerUpCard: tt.dealer})
+
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("want %q %v, got %q %v", tt.want, tt.wantOk, got, ok)
+			}
+		})
+	}
+}
+
+func TestPerfectPairs_Evaluate(t *testing.T) {
+	tests := []struct {
+		name   string
+		player []deck.Card
+		want   Combination
+		wantOk bool
+	}{
+		{
+			name:   "perfect pair",
+			player: []deck.Card{{Rank: deck.Queen, Suit: deck.Heart}, {Rank: deck.Queen, Suit: deck.Heart}},
+			want:   PerfectPair,
+			wantOk: true,
+		},
+		{
+			name:   "colored pair",
+			player: []deck.Card{{Rank: deck.Queen, Suit: deck.Heart}, {Rank: deck.Queen, Suit: deck.Diamond}},
+			want:   ColoredPair,
+			wantOk: true,
+		},
+		{
+			name:   "mixed pair",
+			player: []deck.Card{{Rank: deck.Queen, Suit: deck.Heart}, {Rank: deck.Queen, Suit: deck.Spade}},
+			want:   MixedPair,
+			wantOk: true,
+		},
+		{
+			name:   "ten and king are no pair",
+			player: []deck.Card{{Rank: deck.Ten, Suit: deck.Heart}, {Rank: deck.King, Suit: deck.Heart}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NewPerfectPairs(nil).Evaluate(SideBetCards{Player: tt.player})
+
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("want %q %v, got %q %v", tt.want, tt.wantOk, got, ok)
+			}
+		})
+	}
+}
+
+func Test_validateSideBet(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  Money
+		mainBet Money
+		wantErr error
+	}{
+		{name: "valid", amount: 500, mainBet: 1000},
+		{name: "as high as the main bet", amount: 1000, mainBet: 1000},
+		{name: "higher than the main bet", amount: 1001, mainBet: 1000, wantErr: ErrBetAboveMaximum},
+		{name: "no main bet", amount: 500, wantErr: ErrNoMainBet},
+		{name: "nothing", amount: 0, mainBet: 1000, wantErr: ErrNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateSideBet(tt.amount, tt.mainBet); !errors.Is(err, tt.wantErr) {
+				t.Errorf("want %#v, got %#v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestBusterBlackjack_Evaluate(t *testing.T) {
+	tests := []struct {
+		name   string
+		dealer []deck.Card
+		want   Combination
+		wantOk bool
+	}{
+		{
+			name:   "dealer does not bust",
+			dealer: []deck.Card{{Rank: deck.Ten}, {Rank: deck.Seven}},
+		},
+		{
+			name:   "three card bust",
+			dealer: []deck.Card{{Rank: deck.Ten}, {Rank: deck.Six}, {Rank: deck.Nine}},
+			want:   BustWith(3),
+			wantOk: true,
+		},
+		{
+			name:   "five card bust",
+			dealer: []deck.Card{{Rank: deck.Two}, {Rank: deck.Three}, {Rank: deck.Four}, {Rank: deck.Five}, {Rank: deck.King}},
+			want:   BustWith(5),
+			wantOk: true,
+		},
+		{
+			name: "nine card bust pays like eight",
+			dealer: []deck.Card{
+				{Rank: deck.Ace}, {Rank: deck.Ace}, {Rank: deck.Two}, {Rank: deck.Two}, {Rank: deck.Ace},
+				{Rank: deck.Ace}, {Rank: deck.Two}, {Rank: deck.Five}, {Rank: deck.Ten},
+			},
+			want:   BustWith(8),
+			wantOk: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NewBusterBlackjack(nil).Evaluate(SideBetCards{Dealer: tt.dealer})
+
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("want %q %v, got %q %v", tt.want, tt.wantOk, got, ok)
+			}
+		})
+	}
+}
+
+func TestLuckyLadies_Evaluate(t *testing.T) {
+	tests := []struct {
+		name   string
+		player []deck.Card
+		dealer []deck.Card
+		want   Combination
+		wantOk bool
+	}{
+		{
+			name:   "queen of hearts pair with dealer black jack",
+			player: []deck.Card{{Rank: deck.Queen, Suit: deck.Heart}, {Rank: deck.Queen, Suit: deck.Heart}},
+			dealer: []deck.Card{{Rank: deck.Ace, Suit: deck.Club}, {Rank: deck.King, Suit: deck.Club}},
+			want:   QueenOfHeartsWithDealerBlackJack,
+			wantOk: true,
+		},
+		{
+			name:   "queen of hearts pair",
+			player: []deck.Card{{Rank: deck.Queen, Suit: deck.Heart}, {Rank: deck.Queen, Suit: deck.Heart}},
+			dealer: []deck.Card{{Rank: deck.Nine, Suit: deck.Club}, {Rank: deck.King, Suit: deck.Club}},
+			want:   QueenOfHeartsPair,
+			wantOk: true,
+		},
+		{
+			name:   "matched 20",
+			player: []deck.Card{{Rank: deck.Jack, Suit: deck.Spade}, {Rank: deck.Jack, Suit: deck.Spade}},
+			want:   MatchedTwenty,
+			wantOk: true,
+		},
+		{
+			name:   "suited 20",
+			player: []deck.Card{{Rank: deck.Ace, Suit: deck.Spade}, {Rank: deck.Nine, Suit: deck.Spade}},
+			want:   SuitedTwenty,
+			wantOk: true,
+		},
+		{
+			name:   "any 20",
+			player: []deck.Card{{Rank: deck.King, Suit: deck.Spade}, {Rank: deck.Ten, Suit: deck.Heart}},
+			want:   AnyTwenty,
+			wantOk: true,
+		},
+		{
+			name:   "19",
+			player: []deck.Card{{Rank: deck.King, Suit: deck.Spade}, {Rank: deck.Nine, Suit: deck.Heart}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NewLuckyLadies(nil).Evaluate(SideBetCards{Player: tt.player, Dealer: tt.dealer})
+
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("want %q %v, got %q %v", tt.want, tt.wantOk, got, ok)
+			}
+		})
+	}
+}
+
+func TestRoyalMatch_Evaluate(t *testing.T) {
+	tests := []struct {
+		name   string
+		player []deck.Card
+		want   Combination
+		wantOk bool
+	}{
+		{
+			name:   "suited king and queen",
+			player: []deck.Card{{Rank: deck.Queen, Suit: deck.Club}, {Rank: deck.King, Suit: deck.Club}},
+			want:   RoyalMatchKingQueen,
+			wantOk: true,
+		},
+		{
+			name:   "suited",
+			player: []deck.Card{{Rank: deck.Two, Suit: deck.Club}, {Rank: deck.King, Suit: deck.Club}},
+			want:   EasyMatch,
+			wantOk: true,
+		},
+		{
+			name:   "not suited",
+			player: []deck.Card{{Rank: deck.Queen, Suit: deck.Heart}, {Rank: deck.King, Suit: deck.Club}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NewRoyalMatch(nil).Evaluate(SideBetCards{Player: tt.player})
+
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("want %q %v, got %q %v", tt.want, tt.wantOk, got, ok)
+			}
+		})
+	}
+}
+
+func Test_timing(t *testing.T) {
+	tests := []struct {
+		bet  SideBet
+		want Timing
+	}{
+		{bet: NewTwentyOnePlusThree(nil), want: AfterDeal},
+		{bet: NewPerfectPairs(nil), want: AfterDeal},
+		{bet: NewRoyalMatch(nil), want: AfterDeal},
+		{bet: NewBusterBlackjack(nil), want: AfterDealer},
+		{bet: NewLuckyLadies(nil), want: AfterDealer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.bet.Name(), func(t *testing.T) {
+			if got := timing(tt.bet); got != tt.want {
+				t.Errorf("want %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
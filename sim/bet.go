@@ -0,0 +1,112 @@
+package sim
+
+import (
+	"math"
+
+	"github.com/Hydoc/blackjack"
+)
+
+// Round is what a BetStrategy sizes the bet of the next round by.
+type Round struct {
+	// Number counts the rounds played before, the first round is 0.
+	Number int
+	// Balance is the bankroll left. Without a bankroll it is the net result so far.
+	Balance blackjack.Money
+	// Last is the net result of the previous round.
+	Last blackjack.Money
+	// Streak counts the rounds won in a row, or lost in a row if it is negative. A push keeps the streak.
+	Streak int
+	// TrueCount is the true count of the Config's Counter, 0 without one.
+	TrueCount float64
+}
+
+// BetStrategy sizes the main bet of every round.
+type BetStrategy interface {
+	Bet(r Round) blackjack.Money
+}
+
+// BetFunc adapts a function to a BetStrategy.
+type BetFunc func(r Round) blackjack.Money
+
+func (f BetFunc) Bet(r Round) blackjack.Money {
+	return f(r)
+}
+
+// Flat bets the same amount every round.
+func Flat(amount blackjack.Money) BetStrategy {
+	return BetFunc(func(Round) blackjack.Money {
+		return amount
+	})
+}
+
+// maxDoubled is the bet Martingale stops doubling at without a maximum. A round can cost a few times the bet after
+// splits and doubles, so it leaves plenty of room before Money overflows.
+const maxDoubled = blackjack.Money(math.MaxInt64 >> 8)
+
+// Martingale doubles the bet after every lost round and returns to the base after a win. The bet never exceeds
+// the maximum, 0 for no maximum, in which case it stops doubling long before it overflows.
+func Martingale(base, maximum blackjack.Money) BetStrategy {
+	if maximum <= 0 || maximum > maxDoubled {
+		maximum = maxDoubled
+	}
+	return BetFunc(func(r Round) blackjack.Money {
+		bet := base
+		for range -r.Streak {
+			if bet > maximum/2 {
+				return maximum
+			}
+			bet *= 2
+		}
+		return min(bet, maximum)
+	})
+}
+
+// Paroli doubles the bet after every won round and returns to the base after a loss or three wins in a row.
+func Paroli(base blackjack.Money) BetStrategy {
+	return BetFunc(func(r Round) blackjack.Money {
+		return base << (max(r.Streak, 0) % 3)
+	})
+}
+
+// OneThreeTwoSix bets 1, 3, 2 and 6 units on consecutive wins and returns to one unit after a loss or the sixth.
+func OneThreeTwoSix(unit blackjack.Money) BetStrategy {
+	units := [4]blackjack.Money{1, 3, 2, 6}
+	return BetFunc(func(r Round) blackjack.Money {
+		return unit * units[max(r.Streak, 0)%4]
+	})
+}
+
+// Kelly bets the fraction of the bankroll which maximizes its growth, scaled by fraction, for example 0.5 for half
+// Kelly. The advantage is estimated as half a percent per true count above the house edge, the variance of a hand
+// as 1.33. Without an advantage, or below it, the minimum is bet. Bets are whole multiples of the minimum, which
+// has to be positive. Run fails with ErrInvalidBet otherwise.
+func Kelly(minimum blackjack.Money, houseEdge, fraction float64) BetStrategy {
+	return BetFunc(func(r Round) blackjack.Money {
+		if minimum <= 0 {
+			return 0
+		}
+		advantage := 0.005*r.TrueCount - houseEdge
+		if advantage <= 0 || r.Balance <= 0 {
+			return minimum
+		}
+
+		bet := blackjack.Money(fraction * advantage / 1.33 * float64(r.Balance))
+		return max(minimum, bet/minimum*minimum)
+	})
+}
+
+// Spread bets the units of the true count rounded down, the first at a count of 0 or below and the last for every
+// count beyond the units:
+//
+//	sim.Spread(10*blackjack.Unit, 1, 1, 2, 4, 8)
+//
+// bets 10 up to a true count of 1, 20 at 2, 40 at 3 and 80 from 4 on.
+func Spread(unit blackjack.Money, units ...int) BetStrategy {
+	return BetFunc(func(r Round) blackjack.Money {
+		if len(units) == 0 {
+			return unit
+		}
+		i := min(max(int(math.Floor(r.TrueCount)), 0), len(units)-1)
+		return unit * blackjack.Money(units[i])
+	})
+}
@@ -0,0 +1,55 @@
+package sim
+
+import (
+	"testing"
+
+	"github.com/Hydoc/blackjack"
+)
+
+func TestBetStrategy_Bet(t *testing.T) {
+	const unit = 10 * blackjack.Unit
+
+	tests := []struct {
+		name     string
+		strategy BetStrategy
+		round    Round
+		want     blackjack.Money
+	}{
+		{name: "flat", strategy: Flat(unit), round: Round{Streak: -3}, want: unit},
+		{name: "martingale after a win", strategy: Martingale(unit, 0), round: Round{Streak: 2}, want: unit},
+		{name: "martingale after three losses", strategy: Martingale(unit, 0), round: Round{Streak: -3}, want: 8 * unit},
+		{name: "martingale at its maximum", strategy: Martingale(unit, 50*blackjack.Unit), round: Round{Streak: -3}, want: 50 * blackjack.Unit},
+		{name: "martingale after a long losing streak", strategy: Martingale(unit, 0), round: Round{Streak: -100}, want: maxDoubled},
+		{name: "paroli after a loss", strategy: Paroli(unit), round: Round{Streak: -1}, want: unit},
+		{name: "paroli after two wins", strategy: Paroli(unit), round: Round{Streak: 2}, want: 4 * unit},
+		{name: "paroli after three wins", strategy: Paroli(unit), round: Round{Streak: 3}, want: unit},
+		{name: "1-3-2-6 after a win", strategy: OneThreeTwoSix(unit), round: Round{Streak: 1}, want: 3 * unit},
+		{name: "1-3-2-6 after three wins", strategy: OneThreeTwoSix(unit), round: Round{Streak: 3}, want: 6 * unit},
+		{name: "1-3-2-6 after four wins", strategy: OneThreeTwoSix(unit), round: Round{Streak: 4}, want: unit},
+		{name: "kelly without a minimum", strategy: Kelly(0, 0.005, 1), round: Round{Balance: 10_000 * blackjack.Unit, TrueCount: 5}, want: 0},
+		{name: "kelly without advantage", strategy: Kelly(unit, 0.005, 1), round: Round{Balance: 10_000 * blackjack.Unit, TrueCount: 1}, want: unit},
+		{
+			name:     "kelly with advantage",
+			strategy: Kelly(unit, 0.005, 1),
+			round:    Round{Balance: 10_000 * blackjack.Unit, TrueCount: 3},
+			want:     70 * blackjack.Unit,
+		},
+		{
+			name:     "half kelly",
+			strategy: Kelly(unit, 0.005, 0.5),
+			round:    Round{Balance: 10_000 * blackjack.Unit, TrueCount: 3},
+			want:     30 * blackjack.Unit,
+		},
+		{name: "spread at a negative count", strategy: Spread(unit, 1, 1, 2, 4, 8), round: Round{TrueCount: -2.5}, want: unit},
+		{name: "spread at 2.9", strategy: Spread(unit, 1, 1, 2, 4, 8), round: Round{TrueCount: 2.9}, want: 2 * unit},
+		{name: "spread beyond its units", strategy: Spread(unit, 1, 1, 2, 4, 8), round: Round{TrueCount: 9}, want: 8 * unit},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.strategy.Bet(test.round); got != test.want {
+				t.Errorf("want %s, got %s", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+// Package sim plays a blackjack.Table headlessly for many rounds and reports how the player did.
+package sim
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/blackjack/strategy"
+	"github.com/Hydoc/deck"
+)
+
+var (
+	ErrNoStrategy = errors.New("no strategy")
+	ErrNoRounds   = errors.New("no rounds to play")
+	ErrInvalidBet = errors.New("bet strategy sized no bet")
+)
+
+// Strategy decides the action for the cards against the dealer's up card out of the legal actions.
+// A strategy.Strategy can be used directly.
+type Strategy interface {
+	Action(cards []deck.Card, up deck.Card, legal ...blackjack.Action) blackjack.Action
+}
+
+// StrategyFunc adapts a function to a Strategy.
+type StrategyFunc func(cards []deck.Card, up deck.Card, legal ...blackjack.Action) blackjack.Action
+
+func (f StrategyFunc) Action(cards []deck.Card, up deck.Card, legal ...blackjack.Action) blackjack.Action {
+	return f(cards, up, legal...)
+}
+
+// Config describes a simulation.
+type Config struct {
+	Rules    blackjack.Rules
+	Strategy Strategy
+	Rounds   int
+	// Bet is the main bet of every round unless a BetStrategy sizes it. Zero bets one Unit.
+	Bet blackjack.Money
+	// BetStrategy sizes the main bet of every round.
+	BetStrategy BetStrategy
+	// Counter provides the true count to the BetStrategy, usually a counting.Counter which is one of the
+	// Observers as well.
+	Counter strategy.TrueCounter
+	// Bankroll is the money the player starts with. The simulation stops early once it can not cover the table
+	// minimum and a bet above the balance is reduced to it. Zero plays with an unlimited bankroll.
+	Bankroll blackjack.Money
+	// Seed makes the shuffles repeatable. Zero seeds randomly.
+	Seed uint64
+	// Observers watch the cards of the table, for example a counting.Counter the strategy deviates by.
+	Observers []blackjack.Observer
+	// OnRound is called after every round with its main bet and net result, for example to analyze the session
+	// with package bankroll.
+	OnRound func(bet, net blackjack.Money)
+}
+
+// Result holds the statistics of a simulation. Money is counted in minor units.
+type Result struct {
+	Rounds int
+	// Hands counts the played hands, a split adds one.
+	Hands      int
+	Wins       int
+	Pushes     int
+	Losses     int
+	BlackJacks int
+	Surrenders int
+	// Wagered is the sum of every main bet, double and split.
+	Wagered blackjack.Money
+	Net     blackjack.Money
+	// Bankroll is the money left of the Config's bankroll, Ruined reports whether it ran out before every round
+	// was played.
+	Bankroll blackjack.Money
+	Ruined   bool
+
+	bet        blackjack.Money
+	initial    blackjack.Money
+	sumSquares float64
+}
+
+// HouseEdge returns the player's average loss relative to the main bets.
+func (r Result) HouseEdge() float64 {
+	if r.initial == 0 {
+		return 0
+	}
+	return -float64(r.Net) / float64(r.initial)
+}
+
+// Variance returns the variance of the result of a round in squared units of the Config's Bet.
+func (r Result) Variance() float64 {
+	if r.Rounds == 0 {
+		return 0
+	}
+	mean := float64(r.Net) / float64(r.bet) / float64(r.Rounds)
+	return r.sumSquares/float64(r.Rounds) - mean*mean
+}
+
+// StdDev returns the standard deviation of the result of a round in units of the Config's Bet.
+func (r Result) StdDev() float64 {
+	return math.Sqrt(r.Variance())
+}
+
+// WinRate returns the share of hands which won, black jacks included.
+func (r Result) WinRate() float64 {
+	return r.rate(r.Wins + r.BlackJacks)
+}
+
+// PushRate returns the share of hands which pushed.
+func (r Result) PushRate() float64 {
+	return r.rate(r.Pushes)
+}
+
+// LossRate returns the share of hands which lost, surrendered hands included.
+func (r Result) LossRate() float64 {
+	return r.rate(r.Losses + r.Surrenders)
+}
+
+// BlackJackRate returns the share of hands which won with a black jack.
+func (r Result) BlackJackRate() float64 {
+	return r.rate(r.BlackJacks)
+}
+
+func (r Result) rate(n int) float64 {
+	if r.Hands == 0 {
+		return 0
+	}
+	return float64(n) / float64(r.Hands)
+}
+
+// Run plays the rounds of the configuration with a single player.
+func Run(cfg Config) (Result, error) {
+	if cfg.Strategy == nil {
+		return Result{}, ErrNoStrategy
+	}
+	if cfg.Rounds <= 0 {
+		return Result{}, ErrNoRounds
+	}
+
+	bet := cfg.Bet
+	if bet == 0 {
+		bet = blackjack.Unit
+	}
+
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+
+	bets := cfg.BetStrategy
+	if bets == nil {
+		bets = Flat(bet)
+	}
+
+	w := newWallet(cfg.Bankroll)
+	opts := []func(t *blackjack.Table) *blackjack.Table{
+		blackjack.WithRules(cfg.Rules),
+		blackjack.WithRand(rand.New(rand.NewPCG(seed, seed))),
+		blackjack.WithLedger(nil),
+	}
+	for _, o := range cfg.Observers {
+		opts = append(opts, blackjack.WithObserver(o))
+	}
+	table := blackjack.New(opts...)
+	player := blackjack.NewPlayer(0, blackjack.WithWallet(w))
+	if err := table.Join(player); err != nil {
+		return Result{}, err
+	}
+
+	result := Result{bet: bet}
+	var round Round
+	for range cfg.Rounds {
+		round.Balance = cfg.Bankroll + result.Net
+		if cfg.Counter != nil {
+			round.TrueCount = cfg.Counter.TrueCount()
+		}
+
+		amount := bets.Bet(round)
+		if amount <= 0 {
+			w.count(&result)
+			return result, fmt.Errorf("round %d: %w", round.Number, ErrInvalidBet)
+		}
+		if cfg.Bankroll > 0 {
+			if round.Balance <= 0 || round.Balance < cfg.Rules.MinBet {
+				result.Ruined = true
+				break
+			}
+			amount = min(amount, round.Balance)
+		}
+
+		before := w.Balance()
+		if err := play(table, player, cfg.Strategy, amount); err != nil {
+			w.count(&result)
+			return result, err
+		}
+
+		net := w.Balance() - before
+		result.Rounds++
+		result.Net += net
+		result.initial += amount
+		units := float64(net) / float64(bet)
+		result.sumSquares += units * units
+		if cfg.OnRound != nil {
+			cfg.OnRound(amount, net)
+		}
+
+		round.Number++
+		round.Last = net
+		switch {
+		case net > 0:
+			round.Streak = max(round.Streak, 0) + 1
+		case net < 0:
+			round.Streak = min(round.Streak, 0) - 1
+		}
+	}
+
+	if cfg.Bankroll > 0 {
+		result.Bankroll = cfg.Bankroll + result.Net
+	}
+	w.count(&result)
+	return result, nil
+}
+
+// play plays one round at the table.
+func play(table *blackjack.Table, player *blackjack.Player, strategy Strategy, bet blackjack.Money) error {
+	if err := table.Bet(player, bet); err != nil {
+		return err
+	}
+	if err := table.Start(); err != nil {
+		return err
+	}
+
+	for table.InProgress() {
+		up, _ := table.UpCard()
+		action := strategy.Action(player.Cards(), up, table.LegalActions()...)
+		if err := apply(table, action); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func apply(table *blackjack.Table, action blackjack.Action) error {
+	switch action {
+	case blackjack.ActionHit:
+		return table.Hit()
+	case blackjack.ActionDoubleDown:
+		return table.DoubleDown()
+	case blackjack.ActionSplit:
+		return table.Split()
+	case blackjack.ActionSurrender:
+		return table.Surrender()
+	default:
+		return table.Stand()
+	}
+}
@@ -0,0 +1,196 @@
+package sim
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/blackjack/counting"
+	"github.com/Hydoc/blackjack/strategy"
+	"github.com/Hydoc/deck"
+)
+
+var standardRules = blackjack.Rules{
+	Decks:            6,
+	DoubleOn:         blackjack.DoubleAnyTwo,
+	DoubleAfterSplit: true,
+	Surrender:        true,
+	Peek:             true,
+}
+
+func alwaysStand() Strategy {
+	return StrategyFunc(func([]deck.Card, deck.Card, ...blackjack.Action) blackjack.Action {
+		return blackjack.ActionStand
+	})
+}
+
+func TestRun(t *testing.T) {
+	result, err := Run(Config{
+		Rules:    standardRules,
+		Strategy: strategy.New(standardRules),
+		Rounds:   200_000,
+		Seed:     1,
+	})
+	if err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	if result.Rounds != 200_000 || result.Hands < result.Rounds {
+		t.Errorf("want 200000 rounds with at least as many hands, got %d rounds and %d hands", result.Rounds, result.Hands)
+	}
+
+	// basic strategy is within a percent of even, standing on everything is not
+	if edge := result.HouseEdge(); math.Abs(edge) > 0.01 {
+		t.Errorf("want house edge below 1%%, got %.3f%%", 100*edge)
+	}
+
+	if got := result.WinRate() + result.PushRate() + result.LossRate(); math.Abs(got-1) > 1e-9 {
+		t.Errorf("want rates to sum up to 1, got %f", got)
+	}
+
+	if got := result.BlackJackRate(); got < 0.04 || got > 0.05 {
+		t.Errorf("want black jacks in about 4.5%% of the hands, got %.2f%%", 100*got)
+	}
+
+	if got := result.StdDev(); got < 1 || got > 1.3 {
+		t.Errorf("want a standard deviation of about 1.15, got %.3f", got)
+	}
+}
+
+func TestRun_Deviations(t *testing.T) {
+	counter := counting.New(counting.HiLo, standardRules)
+	indices := append(slices.Clone(strategy.Fab4), strategy.Illustrious18...)
+
+	result, err := Run(Config{
+		Rules:     standardRules,
+		Strategy:  strategy.NewDeviations(strategy.New(standardRules), counter, indices...),
+		Rounds:    100_000,
+		Seed:      1,
+		Observers: []blackjack.Observer{counter},
+	})
+	if err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	if edge := result.HouseEdge(); math.Abs(edge) > 0.01 {
+		t.Errorf("want house edge below 1%%, got %.3f%%", 100*edge)
+	}
+	if got := counter.DecksRemaining(); got >= 6 {
+		t.Errorf("want the counter to have seen cards, got %.2f decks remaining", got)
+	}
+}
+
+func TestRun_Bankroll(t *testing.T) {
+	result, err := Run(Config{
+		Rules:       standardRules,
+		Strategy:    alwaysStand(),
+		BetStrategy: Martingale(blackjack.Unit, 0),
+		Rounds:      100_000,
+		Bankroll:    100 * blackjack.Unit,
+		Seed:        1,
+	})
+	if err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	if !result.Ruined || result.Rounds == 100_000 {
+		t.Errorf("want a martingale standing on everything to be ruined early, got %d rounds", result.Rounds)
+	}
+	if result.Bankroll != 100*blackjack.Unit+result.Net || result.Bankroll > 0 {
+		t.Errorf("want the bankroll to be used up, got %s", result.Bankroll)
+	}
+}
+
+func TestRun_BetStrategy(t *testing.T) {
+	counter := counting.New(counting.HiLo, standardRules)
+
+	result, err := Run(Config{
+		Rules:       standardRules,
+		Strategy:    strategy.New(standardRules),
+		BetStrategy: Spread(blackjack.Unit, 1, 1, 2, 4, 8),
+		Counter:     counter,
+		Observers:   []blackjack.Observer{counter},
+		Rounds:      10_000,
+		Seed:        1,
+	})
+	if err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	if result.initial <= 10_000*blackjack.Unit {
+		t.Errorf("want the spread to bet more than a unit at high counts, got %s in total", result.initial)
+	}
+	if result.Ruined || result.Bankroll != 0 {
+		t.Errorf("want no bankroll without one, got %#v", result)
+	}
+}
+
+func TestRun_AlwaysStand(t *testing.T) {
+	result, err := Run(Config{Rules: standardRules, Strategy: alwaysStand(), Rounds: 100_000, Seed: 1, Bet: 10 * blackjack.Unit})
+	if err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	if edge := result.HouseEdge(); edge < 0.12 || edge > 0.2 {
+		t.Errorf("want a house edge of about 16%%, got %.3f%%", 100*edge)
+	}
+
+	if result.Wagered != 10*blackjack.Unit*blackjack.Money(result.Rounds) {
+		t.Errorf("want only main bets, got %s", result.Wagered)
+	}
+}
+
+func TestRun_Seed(t *testing.T) {
+	cfg := Config{Rules: standardRules, Strategy: strategy.New(standardRules), Rounds: 1000, Seed: 42}
+
+	first, _ := Run(cfg)
+	second, _ := Run(cfg)
+
+	if first != second {
+		t.Errorf("want the same result for the same seed, got %#v and %#v", first, second)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want error
+	}{
+		{name: "no strategy", cfg: Config{Rounds: 1}, want: ErrNoStrategy},
+		{name: "no rounds", cfg: Config{Strategy: alwaysStand()}, want: ErrNoRounds},
+		{name: "no bet", cfg: Config{Strategy: alwaysStand(), BetStrategy: Kelly(0, 0.005, 1), Rounds: 1}, want: ErrInvalidBet},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Run(tt.cfg); !errors.Is(err, tt.want) {
+				t.Errorf("want %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestRun_Allocations(t *testing.T) {
+	s := strategy.New(standardRules)
+	const rounds = 10_000
+
+	allocs := testing.AllocsPerRun(5, func() {
+		Run(Config{Rules: standardRules, Strategy: s, Rounds: rounds, Seed: 1})
+	})
+
+	// a round allocates its hands, the dealer's hand and the legal actions, reshuffling adds a little
+	if perRound := allocs / rounds; perRound > 8 {
+		t.Errorf("want at most %d allocations per round, got %.2f", 8, perRound)
+	}
+}
+
+func BenchmarkRun(b *testing.B) {
+	s := strategy.New(standardRules)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	Run(Config{Rules: standardRules, Strategy: s, Rounds: b.N, Seed: 1})
+}
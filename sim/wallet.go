@@ -0,0 +1,68 @@
+package sim
+
+import (
+	"math"
+
+	"github.com/Hydoc/blackjack"
+)
+
+// wallet counts the outcomes of the hands by the transactions. Without a bankroll it never runs out of money.
+type wallet struct {
+	balance blackjack.Money
+
+	hands      int
+	wins       int
+	pushes     int
+	blackJacks int
+	surrenders int
+	wagered    blackjack.Money
+}
+
+func newWallet(bankroll blackjack.Money) *wallet {
+	if bankroll == 0 {
+		bankroll = math.MaxInt64 / 2
+	}
+	return &wallet{balance: bankroll}
+}
+
+func (w *wallet) Balance() blackjack.Money {
+	return w.balance
+}
+
+func (w *wallet) Debit(tx blackjack.Transaction) error {
+	switch tx.Reason {
+	case blackjack.ReasonBet, blackjack.ReasonSplit:
+		w.hands++
+		w.wagered += tx.Amount
+	case blackjack.ReasonDoubleDown:
+		w.wagered += tx.Amount
+	}
+	w.balance -= tx.Amount
+	return nil
+}
+
+func (w *wallet) Credit(tx blackjack.Transaction) error {
+	switch tx.Reason {
+	case blackjack.ReasonWin, blackjack.ReasonBonus:
+		w.wins++
+	case blackjack.ReasonBlackJack:
+		w.blackJacks++
+	case blackjack.ReasonPush:
+		w.pushes++
+	case blackjack.ReasonSurrender:
+		w.surrenders++
+	}
+	w.balance += tx.Amount
+	return nil
+}
+
+// count copies the outcomes into the result. Every hand which was not paid lost.
+func (w *wallet) count(r *Result) {
+	r.Hands = w.hands
+	r.Wins = w.wins
+	r.Pushes = w.pushes
+	r.BlackJacks = w.blackJacks
+	r.Surrenders = w.surrenders
+	r.Losses = w.hands - w.wins - w.pushes - w.blackJacks - w.surrenders
+	r.Wagered = w.wagered
+}
@@ -0,0 +1,23 @@
+package blackjack
+
+// Spanish21Rules returns the rules of Spanish 21. It is played with 6 decks without the tens,
+// a player's 21 always wins, late surrender, doubling on any amount of cards, also after a split,
+// redoubling up to three times and bonus payouts for five or more card 21s, 6-7-8 and 7-7-7.
+func Spanish21Rules() Rules {
+	return Rules{
+		Decks:               6,
+		StripTens:           true,
+		DoubleOn:            DoubleAny,
+		DoubleAfterSplit:    true,
+		Redouble:            2,
+		Surrender:           true,
+		PlayerTwentyOneWins: true,
+		TwentyOneBonuses:    true,
+	}
+}
+
+// NewSpanish21 creates a pointer to a Table playing Spanish21Rules. Options are applied on top of the variant,
+// e.g. WithBetLimits.
+func NewSpanish21(opts ...func(t *Table) *Table) *Table {
+	return New(append([]func(t *Table) *Table{WithRules(Spanish21Rules())}, opts...)...)
+}
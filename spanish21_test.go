@@ -0,0 +1,172 @@
+package blackjack
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Hydoc/deck"
+)
+
+func TestNewSpanish21(t *testing.T) {
+	table := NewSpanish21(WithBetLimits(5*Unit, 500*Unit))
+
+	if len(table.deck) != 288 {
+		t.Errorf("want %d cards, got %d", 288, len(table.deck))
+	}
+
+	if slices.ContainsFunc(table.deck, func(c deck.Card) bool { return c.Rank == deck.Ten }) {
+		t.Errorf("shoe should not contain tens")
+	}
+
+	if table.rules.MinBet != 5*Unit || !table.rules.PlayerTwentyOneWins {
+		t.Errorf("want Spanish 21 rules with limits, got %#v", table.rules)
+	}
+}
+
+func TestSpanish21_Settle(t *testing.T) {
+	tests := []struct {
+		name        string
+		deck        []deck.Card
+		play        func(table *Table) error
+		wantBalance Money
+	}{
+		{
+			name: "player 21 wins against dealer 21",
+			deck: stack(
+				deck.Card{Rank: deck.Nine, Suit: deck.Heart},
+				deck.Card{Rank: deck.King, Suit: deck.Club},
+				deck.Card{Rank: deck.Two, Suit: deck.Heart},
+				deck.Card{Rank: deck.Five, Suit: deck.Club},
+				deck.Card{Rank: deck.Jack, Suit: deck.Heart},
+				deck.Card{Rank: deck.Six, Suit: deck.Club},
+			),
+			play: func(table *Table) error {
+				if err := table.Hit(); err != nil {
+					return err
+				}
+				return table.Stand()
+			},
+			wantBalance: 110 * Unit,
+		},
+		{
+			name: "6-7-8 pays a bonus",
+			deck: stack(
+				deck.Card{Rank: deck.Six, Suit: deck.Heart},
+				deck.Card{Rank: deck.King, Suit: deck.Club},
+				deck.Card{Rank: deck.Seven, Suit: deck.Club},
+				deck.Card{Rank: deck.Eight, Suit: deck.Club},
+				deck.Card{Rank: deck.Eight, Suit: deck.Heart},
+			),
+			play: func(table *Table) error {
+				if err := table.Hit(); err != nil {
+					return err
+				}
+				return table.Stand()
+			},
+			wantBalance: 115 * Unit,
+		},
+		{
+			name: "redouble until standing",
+			deck: stack(
+				deck.Card{Rank: deck.Two, Suit: deck.Heart},
+				deck.Card{Rank: deck.King, Suit: deck.Club},
+				deck.Card{Rank: deck.Three, Suit: deck.Club},
+				deck.Card{Rank: deck.Eight, Suit: deck.Club},
+				deck.Card{Rank: deck.Four, Suit: deck.Heart},
+				deck.Card{Rank: deck.Two, Suit: deck.Spade},
+				deck.Card{Rank: deck.Nine, Suit: deck.Spade},
+			),
+			play: func(table *Table) error {
+				if err := table.DoubleDown(); err != nil {
+					return err
+				}
+				if err := table.Hit(); !errors.Is(err, ErrNotAllowed) {
+					return errors.New("doubled hand should not be hit")
+				}
+				if err := table.DoubleDown(); err != nil {
+					return err
+				}
+				if err := table.DoubleDown(); err != nil {
+					return err
+				}
+				if table.InProgress() {
+					return errors.New("hand should stand after the last redouble")
+				}
+				return nil
+			},
+			wantBalance: 100*Unit + 80*Unit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewSpanish21()
+			table.deck = tt.deck
+			player := NewPlayer(100 * Unit)
+			table.Join(player)
+			table.Bet(player, 10*Unit)
+
+			if err := table.Start(); err != nil {
+				t.Fatalf("want nil, got %v", err)
+			}
+
+			if err := tt.play(table); err != nil {
+				t.Fatalf("want nil, got %v", err)
+			}
+
+			if got := player.Balance(); got != tt.wantBalance {
+				t.Errorf("want %s, got %s", tt.wantBalance, got)
+			}
+		})
+	}
+}
+
+func TestTable_DoubleAfterSplit(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   Rules
+		wantErr error
+	}{
+		{name: "allowed", rules: Rules{DoubleAfterSplit: true}},
+		{name: "not allowed", rules: Rules{}, wantErr: ErrNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player := NewPlayer(100 * Unit)
+			player.hands = newSplitHands(
+				deck.Card{Rank: deck.Five, Suit: deck.Heart},
+				deck.Card{Rank: deck.Five, Suit: deck.Club},
+				10*Unit,
+			)
+			player.Hit(deck.Card{Rank: deck.Six, Suit: deck.Club})
+			table := &Table{
+				rules:      tt.rules,
+				dealer:     newDealer(),
+				turnPlayer: player,
+				players:    [7]*Player{player},
+				deck:       deck.New(),
+			}
+
+			if err := table.DoubleDown(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("want %#v, got %#v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTable_Reshuffle(t *testing.T) {
+	table := New(WithRules(Rules{Decks: 1}))
+	table.deck = table.deck[:14]
+	player := NewPlayer(100 * Unit)
+	table.Join(player)
+	table.Start()
+	for table.InProgress() {
+		table.Stand()
+	}
+
+	if len(table.deck) != 52 {
+		t.Errorf("want a fresh shoe of %d cards, got %d", 52, len(table.deck))
+	}
+}
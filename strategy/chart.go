@@ -0,0 +1,191 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/deck"
+)
+
+// EV holds the expected value of every possible action, in units of the original bet.
+type EV map[blackjack.Action]float64
+
+// Best returns the action with the highest expected value out of the legal ones. Without legal actions every action
+// of the EV is considered.
+func (ev EV) Best(legal ...blackjack.Action) (blackjack.Action, float64) {
+	best, value := blackjack.ActionStand, math.Inf(-1)
+	for _, a := range actions {
+		v, ok := ev[a]
+		if !ok || len(legal) > 0 && !contains(legal, a) {
+			continue
+		}
+		if v > value {
+			best, value = a, v
+		}
+	}
+	return best, value
+}
+
+var actions = []blackjack.Action{
+	blackjack.ActionStand,
+	blackjack.ActionHit,
+	blackjack.ActionDoubleDown,
+	blackjack.ActionSplit,
+	blackjack.ActionSurrender,
+}
+
+func contains(legal []blackjack.Action, a blackjack.Action) bool {
+	for _, l := range legal {
+		if l == a {
+			return true
+		}
+	}
+	return false
+}
+
+// Chart is a basic strategy chart generated for a set of rules. Every cell holds the expected values of the actions
+// on the first two cards, which is what the classic chart is derived from.
+type Chart struct {
+	rules blackjack.Rules
+	hard  [22][11]EV
+	soft  [22][11]EV
+	pairs [11][11]EV
+}
+
+// NewChart computes the chart for the rules. The dealer plays from the shoe described by the rules without the
+// up card and, for pairs, without the pair.
+func NewChart(rules blackjack.Rules) *Chart {
+	c := &Chart{rules: rules}
+
+	for up := 1; up <= 10; up++ {
+		s := newShoe(rules)
+		s.remove(up)
+		calc := newCalc(rules, s, up)
+
+		for t := 2; t <= 21; t++ {
+			c.hard[t][up] = calc.evs(t, false)
+		}
+		for t := 11; t <= 21; t++ {
+			c.soft[t][up] = calc.evs(t-10, true)
+		}
+
+		for v := 1; v <= 10; v++ {
+			paired := s
+			paired.remove(v, v)
+			calc := newCalc(rules, paired, up)
+
+			ev := calc.evs(2*v, v == 1)
+			ev[blackjack.ActionSplit] = calc.withBlackJack(calc.split(v), 2)
+			c.pairs[v][up] = ev
+		}
+	}
+
+	return c
+}
+
+// Hard returns the expected values of a hard total against the up card.
+func (c *Chart) Hard(total int, up deck.Rank) EV {
+	if total < 2 || total > 21 {
+		return nil
+	}
+	return c.hard[total][value(up)]
+}
+
+// Soft returns the expected values of a soft total against the up card.
+func (c *Chart) Soft(total int, up deck.Rank) EV {
+	if total < 11 || total > 21 {
+		return nil
+	}
+	return c.soft[total][value(up)]
+}
+
+// Pair returns the expected values of a pair of the rank against the up card.
+func (c *Chart) Pair(rank deck.Rank, up deck.Rank) EV {
+	return c.pairs[value(rank)][value(up)]
+}
+
+// String renders the chart the way it is printed on cards, H for hit, S for stand, D for double down, P for split
+// and R for surrender. Double down and surrender are followed by the action to take if they are not allowed.
+func (c *Chart) String() string {
+	var b strings.Builder
+	header := func(title string) {
+		fmt.Fprintf(&b, "%-6s", title)
+		for _, up := range columns {
+			fmt.Fprintf(&b, "%4s", label(up))
+		}
+		b.WriteString("\n")
+	}
+	row := func(name string, cells [11]EV) {
+		fmt.Fprintf(&b, "%-6s", name)
+		for _, up := range columns {
+			fmt.Fprintf(&b, "%4s", code(cells[up]))
+		}
+		b.WriteString("\n")
+	}
+
+	header("Hard")
+	for t := 5; t <= 20; t++ {
+		row(fmt.Sprint(t), c.hard[t])
+	}
+	header("Soft")
+	for t := 13; t <= 20; t++ {
+		row(fmt.Sprintf("A,%d", t-11), c.soft[t])
+	}
+	header("Pairs")
+	for _, v := range columns {
+		row(fmt.Sprintf("%s,%s", label(v), label(v)), c.pairs[v])
+	}
+
+	return b.String()
+}
+
+// columns orders the up cards like a printed chart, the ace comes last.
+var columns = []int{2, 3, 4, 5, 6, 7, 8, 9, 10, 1}
+
+func label(v int) string {
+	if v == 1 {
+		return "A"
+	}
+	return fmt.Sprint(v)
+}
+
+// code returns the short form of the best action and, for double down and surrender, the fallback.
+func code(ev EV) string {
+	best, _ := ev.Best()
+	switch best {
+	case blackjack.ActionDoubleDown, blackjack.ActionSurrender:
+		legal := []blackjack.Action{blackjack.ActionHit, blackjack.ActionStand}
+		if _, ok := ev[blackjack.ActionSplit]; ok {
+			legal = append(legal, blackjack.ActionSplit)
+		}
+		otherwise, _ := ev.Best(legal...)
+		return letter(best) + strings.ToLower(letter(otherwise))
+	default:
+		return letter(best)
+	}
+}
+
+func letter(a blackjack.Action) string {
+	switch a {
+	case blackjack.ActionHit:
+		return "H"
+	case blackjack.ActionDoubleDown:
+		return "D"
+	case blackjack.ActionSplit:
+		return "P"
+	case blackjack.ActionSurrender:
+		return "R"
+	default:
+		return "S"
+	}
+}
+
+// value returns the value index of the rank, 1 for the ace and 10 for every ten-valued card.
+func value(rank deck.Rank) int {
+	if rank >= deck.Ten {
+		return 10
+	}
+	return int(rank)
+}
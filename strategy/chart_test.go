@@ -0,0 +1,82 @@
+package strategy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/deck"
+)
+
+func TestEV_Best(t *testing.T) {
+	ev := EV{
+		blackjack.ActionStand:      -0.2,
+		blackjack.ActionHit:        0.1,
+		blackjack.ActionDoubleDown: 0.3,
+	}
+
+	tests := []struct {
+		name      string
+		legal     []blackjack.Action
+		want      blackjack.Action
+		wantValue float64
+	}{
+		{name: "any action", want: blackjack.ActionDoubleDown, wantValue: 0.3},
+		{name: "without double down", legal: []blackjack.Action{blackjack.ActionHit, blackjack.ActionStand}, want: blackjack.ActionHit, wantValue: 0.1},
+		{name: "only stand", legal: []blackjack.Action{blackjack.ActionStand, blackjack.ActionSplit}, want: blackjack.ActionStand, wantValue: -0.2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, value := ev.Best(tt.legal...)
+
+			if got != tt.want || value != tt.wantValue {
+				t.Errorf("want %s %f, got %s %f", tt.want, tt.wantValue, got, value)
+			}
+		})
+	}
+}
+
+func TestChart_String(t *testing.T) {
+	c := NewChart(blackjack.Rules{
+		Decks:            6,
+		DoubleOn:         blackjack.DoubleAnyTwo,
+		DoubleAfterSplit: true,
+		Surrender:        true,
+		Peek:             true,
+		DealerHitsSoft17: true,
+	})
+
+	lines := strings.Split(c.String(), "\n")
+	tests := []struct {
+		name string
+		line int
+		want string
+	}{
+		{name: "header", line: 0, want: "Hard     2   3   4   5   6   7   8   9  10   A"},
+		{name: "hard 11", line: 7, want: "11      Dh  Dh  Dh  Dh  Dh  Dh  Dh  Dh  Dh  Dh"},
+		{name: "hard 16", line: 12, want: "16       S   S   S   S   S   H   H  Rh  Rh  Rh"},
+		{name: "soft 18", line: 23, want: "A,7     Ds  Ds  Ds  Ds  Ds   S   S   H   H   H"},
+		{name: "eights", line: 33, want: "8,8      P   P   P   P   P   P   P   P   P  Rp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lines[tt.line]; got != tt.want {
+				t.Errorf("want %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestChart_Pair(t *testing.T) {
+	c := NewChart(blackjack.Rules{Peek: true})
+
+	if c.Pair(deck.King, deck.Two)[blackjack.ActionSplit] != c.Pair(deck.Ten, deck.Two)[blackjack.ActionSplit] {
+		t.Errorf("want every ten-valued pair to be the same")
+	}
+
+	if c.Hard(1, deck.Two) != nil || c.Soft(22, deck.Two) != nil {
+		t.Errorf("want no expected values for impossible totals")
+	}
+}
@@ -0,0 +1,42 @@
+package strategy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Hydoc/blackjack"
+)
+
+func TestHouseEdge(t *testing.T) {
+	sixDecks := blackjack.Rules{Decks: 6, DoubleOn: blackjack.DoubleAnyTwo, DoubleAfterSplit: true, Peek: true}
+	with := func(change func(r *blackjack.Rules)) blackjack.Rules {
+		r := sixDecks
+		change(&r)
+		return r
+	}
+
+	tests := []struct {
+		name  string
+		rules blackjack.Rules
+		want  float64
+	}{
+		{name: "six decks", rules: sixDecks, want: 0.00325},
+		{name: "one deck", rules: with(func(r *blackjack.Rules) { r.Decks = 1 }), want: 0.00105},
+		{name: "eight decks", rules: with(func(r *blackjack.Rules) { r.Decks = 8 }), want: 0.00337},
+		{name: "dealer hits soft 17", rules: with(func(r *blackjack.Rules) { r.DealerHitsSoft17 = true }), want: 0.00543},
+		{name: "black jack pays 6 to 5", rules: with(func(r *blackjack.Rules) { r.BlackJackPayout = blackjack.SixToFive }), want: 0.01685},
+		{name: "no double after split", rules: with(func(r *blackjack.Rules) { r.DoubleAfterSplit = false }), want: 0.00452},
+		{name: "late surrender", rules: with(func(r *blackjack.Rules) { r.Surrender = true }), want: 0.00249},
+		{name: "no peek", rules: with(func(r *blackjack.Rules) { r.Peek = false }), want: 0.00438},
+		{name: "double on 9 to 11", rules: with(func(r *blackjack.Rules) { r.DoubleOn = blackjack.DoubleNineToEleven }), want: 0.00421},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := HouseEdge(test.rules)
+			if math.Abs(got-test.want) > 0.00001 {
+				t.Errorf("want %#v, got %#v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package strategy
+
+import "github.com/Hydoc/blackjack"
+
+// shoe counts the cards by their value. Index 1 holds the aces and index 10 every ten-valued card.
+type shoe [11]int
+
+func newShoe(rules blackjack.Rules) shoe {
+	decks := rules.Decks
+	if decks == 0 {
+		decks = 6
+	}
+
+	var s shoe
+	for v := 1; v <= 9; v++ {
+		s[v] = 4 * decks
+	}
+	s[10] = 16 * decks
+	if rules.StripTens {
+		s[10] = 12 * decks
+	}
+	return s
+}
+
+func (s *shoe) remove(values ...int) {
+	for _, v := range values {
+		if s[v] > 0 {
+			s[v]--
+		}
+	}
+}
+
+func (s shoe) size() int {
+	n := 0
+	for _, c := range s {
+		n += c
+	}
+	return n
+}
+
+// dealerOutcome holds the probabilities of the dealer's final hands.
+type dealerOutcome struct {
+	// total holds the probabilities of the totals 17 to 22.
+	total     [23]float64
+	bust      float64
+	blackJack float64
+}
+
+// dealerOutcomes plays every possible dealer hand from the up card, drawing from the shoe without replacement.
+// The shoe must not contain the up card anymore.
+func dealerOutcomes(s shoe, up int, hitSoft17 bool) dealerOutcome {
+	var out dealerOutcome
+	drawDealer(&s, s.size(), up, up == 1, 1, 1, hitSoft17, &out)
+	return out
+}
+
+func drawDealer(s *shoe, n, hard int, ace bool, cards int, p float64, hitSoft17 bool, out *dealerOutcome) {
+	total, soft := hard, false
+	if ace && hard+10 <= 21 {
+		total, soft = hard+10, true
+	}
+
+	switch {
+	case cards == 2 && total == 21:
+		out.blackJack += p
+		return
+	case total > 22:
+		out.bust += p
+		return
+	case total >= 18 || total == 17 && !(soft && hitSoft17):
+		out.total[total] += p
+		return
+	}
+
+	for v := 1; v <= 10; v++ {
+		if s[v] == 0 {
+			continue
+		}
+		q := p * float64(s[v]) / float64(n)
+		s[v]--
+		drawDealer(s, n-1, hard+v, ace || v == 1, cards+1, q, hitSoft17, out)
+		s[v]++
+	}
+}
+
+// calc computes the expected values of a hand against one dealer up card. The player draws from the shoe as it
+// was given, the values are conditioned on the dealer not having a black jack.
+type calc struct {
+	rules  blackjack.Rules
+	draw   [11]float64
+	dealer dealerOutcome
+	// blackJack is the probability of a dealer black jack the player can still lose against.
+	blackJack float64
+
+	hits    [22][2]float64
+	hitDone [22][2]bool
+}
+
+func newCalc(rules blackjack.Rules, s shoe, up int) *calc {
+	c := &calc{rules: rules}
+
+	n := float64(s.size())
+	for v := 1; v <= 10; v++ {
+		c.draw[v] = float64(s[v]) / n
+	}
+
+	out := dealerOutcomes(s, up, rules.DealerHitsSoft17)
+	rest := 1 - out.blackJack
+	for t := 17; t <= 22; t++ {
+		out.total[t] /= rest
+	}
+	out.bust /= rest
+	c.dealer = out
+
+	if !rules.Peek {
+		c.blackJack = out.blackJack
+	}
+	return c
+}
+
+// stand returns the expected value of standing on the total.
+func (c *calc) stand(total int) float64 {
+	if total > 21 {
+		return -1
+	}
+
+	tie := 0.0
+	if c.rules.DealerWinsTies {
+		tie = -1
+	}
+
+	ev := c.dealer.bust
+	if !c.rules.DealerPushesOn22 {
+		ev += c.dealer.total[22]
+	}
+	for d := 17; d <= 21; d++ {
+		switch {
+		case total > d:
+			ev += c.dealer.total[d]
+		case total == d:
+			ev += tie * c.dealer.total[d]
+		default:
+			ev -= c.dealer.total[d]
+		}
+	}
+	return ev
+}
+
+// hit returns the expected value of hitting and playing on with the best of hitting and standing.
+func (c *calc) hit(hard int, ace bool) float64 {
+	a := 0
+	if ace {
+		a = 1
+	}
+	if c.hitDone[hard][a] {
+		return c.hits[hard][a]
+	}
+
+	ev := 0.0
+	for v := 1; v <= 10; v++ {
+		next := hard + v
+		if next > 21 {
+			ev -= c.draw[v]
+			continue
+		}
+		ev += c.draw[v] * max(c.stand(total(next, ace || v == 1)), c.hit(next, ace || v == 1))
+	}
+
+	c.hits[hard][a], c.hitDone[hard][a] = ev, true
+	return ev
+}
+
+// double returns the expected value of doubling, counted in units of the original bet.
+func (c *calc) double(hard int, ace bool) float64 {
+	ev := 0.0
+	for v := 1; v <= 10; v++ {
+		ev += c.draw[v] * c.stand(total(hard+v, ace || v == 1))
+	}
+	return 2 * ev
+}
+
+// split returns the expected value of splitting the pair into two hands, counted in units of the original bet.
+func (c *calc) split(v int) float64 {
+	ev := 0.0
+	for w := 1; w <= 10; w++ {
+		hard, ace := v+w, v == 1 || w == 1
+		best := max(c.stand(total(hard, ace)), c.hit(hard, ace))
+		if c.rules.DoubleAfterSplit && canDouble(c.rules.DoubleOn, total(hard, ace)) {
+			best = max(best, c.double(hard, ace))
+		}
+		ev += c.draw[w] * best
+	}
+	return 2 * ev
+}
+
+// evs returns the expected value of every action the rules allow on the first two cards.
+func (c *calc) evs(hard int, ace bool) EV {
+	t := total(hard, ace)
+	ev := EV{
+		blackjack.ActionStand: c.withBlackJack(c.stand(t), 1),
+		blackjack.ActionHit:   c.withBlackJack(c.hit(hard, ace), 1),
+	}
+	if canDouble(c.rules.DoubleOn, t) {
+		ev[blackjack.ActionDoubleDown] = c.withBlackJack(c.double(hard, ace), 2)
+	}
+	if c.rules.Surrender {
+		ev[blackjack.ActionSurrender] = c.withBlackJack(-0.5, 1)
+	}
+	return ev
+}
+
+// withBlackJack weighs the expected value against the dealer black jack which takes the stake.
+func (c *calc) withBlackJack(ev float64, stake float64) float64 {
+	return (1-c.blackJack)*ev - c.blackJack*stake
+}
+
+// total returns the total of the hard total counting one ace as 11 if it does not bust.
+func total(hard int, ace bool) int {
+	if ace && hard+10 <= 21 {
+		return hard + 10
+	}
+	return hard
+}
+
+func canDouble(rule blackjack.DoubleRule, total int) bool {
+	switch rule {
+	case blackjack.DoubleTenToEleven:
+		return total == 10 || total == 11
+	case blackjack.DoubleAnyTwo, blackjack.DoubleAny:
+		return true
+	default:
+		return total >= 9 && total <= 11
+	}
+}
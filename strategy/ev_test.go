@@ -0,0 +1,119 @@
+package strategy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Hydoc/blackjack"
+)
+
+func Test_newShoe(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    blackjack.Rules
+		wantSize int
+		wantTens int
+	}{
+		{name: "default 6 decks", rules: blackjack.Rules{}, wantSize: 312, wantTens: 96},
+		{name: "single deck", rules: blackjack.Rules{Decks: 1}, wantSize: 52, wantTens: 16},
+		{name: "tens stripped", rules: blackjack.Rules{Decks: 6, StripTens: true}, wantSize: 288, wantTens: 72},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newShoe(tt.rules)
+
+			if got := s.size(); got != tt.wantSize {
+				t.Errorf("want %d, got %d", tt.wantSize, got)
+			}
+
+			if got := s[10]; got != tt.wantTens {
+				t.Errorf("want %d, got %d", tt.wantTens, got)
+			}
+		})
+	}
+}
+
+func Test_dealerOutcomes(t *testing.T) {
+	tests := []struct {
+		name          string
+		up            int
+		hitSoft17     bool
+		wantBust      float64
+		wantBlackJack float64
+	}{
+		{name: "6 busts most", up: 6, wantBust: 0.42, wantBlackJack: 0},
+		{name: "ten", up: 10, wantBust: 0.21, wantBlackJack: 0.077},
+		{name: "ace", up: 1, wantBust: 0.12, wantBlackJack: 0.31},
+		{name: "6 busts more hitting soft 17", up: 6, hitSoft17: true, wantBust: 0.44, wantBlackJack: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newShoe(blackjack.Rules{})
+			s.remove(tt.up)
+			out := dealerOutcomes(s, tt.up, tt.hitSoft17)
+
+			sum := out.bust + out.blackJack
+			for _, p := range out.total {
+				sum += p
+			}
+			if math.Abs(sum-1) > 1e-9 {
+				t.Errorf("want probabilities to sum up to 1, got %f", sum)
+			}
+
+			if got := out.bust + out.total[22]; math.Abs(got-tt.wantBust) > 0.01 {
+				t.Errorf("want %.2f, got %.4f", tt.wantBust, got)
+			}
+
+			if math.Abs(out.blackJack-tt.wantBlackJack) > 0.01 {
+				t.Errorf("want %.3f, got %.4f", tt.wantBlackJack, out.blackJack)
+			}
+		})
+	}
+}
+
+func TestCalc_stand(t *testing.T) {
+	s := newShoe(blackjack.Rules{})
+	s.remove(10)
+
+	tests := []struct {
+		name  string
+		rules blackjack.Rules
+		total int
+		want  float64
+	}{
+		{name: "busted", total: 22, want: -1},
+		{name: "dealer 22 counts as bust", total: 16, want: -0.54},
+		{name: "dealer 22 pushes", rules: blackjack.Rules{DealerPushesOn22: true}, total: 16, want: -0.60},
+		{name: "20 against ten", total: 20, want: 0.55},
+		{name: "dealer wins ties", rules: blackjack.Rules{DealerWinsTies: true}, total: 20, want: 0.19},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rules.Peek = true
+			c := newCalc(tt.rules, s, 10)
+
+			if got := c.stand(tt.total); math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("want %.2f, got %.4f", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCalc_withBlackJack(t *testing.T) {
+	s := newShoe(blackjack.Rules{})
+	s.remove(1)
+
+	peek := newCalc(blackjack.Rules{Peek: true}, s, 1)
+	if got := peek.withBlackJack(0.5, 2); got != 0.5 {
+		t.Errorf("want %f, got %f", 0.5, got)
+	}
+
+	noPeek := newCalc(blackjack.Rules{}, s, 1)
+	want := (1-noPeek.blackJack)*0.5 - 2*noPeek.blackJack
+	if got := noPeek.withBlackJack(0.5, 2); got != want {
+		t.Errorf("want %f, got %f", want, got)
+	}
+}
@@ -0,0 +1,249 @@
+package strategy
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/deck"
+)
+
+var ErrInvalidIndex = errors.New("invalid index")
+
+// Index is an index play. At a true count of at least Count the Action is taken on the hand against the up card,
+// below it the best of the other legal actions.
+type Index struct {
+	// Total is the total of the hand, counted soft if Soft is set. It is ignored for a pair.
+	Total int
+	Soft  bool
+	// Pair is the value of the cards of a pair, 1 for aces and 10 for tens. It is 0 for any other hand.
+	Pair int
+	// Up is the value of the dealer's up card, 1 for the ace and 10 for every ten-valued card.
+	Up     int
+	Count  float64
+	Action blackjack.Action
+}
+
+// Illustrious18 are the most valuable index plays for Hi-Lo, except for insurance which the table does not offer.
+var Illustrious18 = []Index{
+	{Total: 16, Up: 10, Count: 0, Action: blackjack.ActionStand},
+	{Total: 15, Up: 10, Count: 4, Action: blackjack.ActionStand},
+	{Pair: 10, Up: 5, Count: 5, Action: blackjack.ActionSplit},
+	{Pair: 10, Up: 6, Count: 4, Action: blackjack.ActionSplit},
+	{Total: 10, Up: 10, Count: 4, Action: blackjack.ActionDoubleDown},
+	{Total: 12, Up: 3, Count: 2, Action: blackjack.ActionStand},
+	{Total: 12, Up: 2, Count: 3, Action: blackjack.ActionStand},
+	{Total: 11, Up: 1, Count: 1, Action: blackjack.ActionDoubleDown},
+	{Total: 9, Up: 2, Count: 1, Action: blackjack.ActionDoubleDown},
+	{Total: 10, Up: 1, Count: 4, Action: blackjack.ActionDoubleDown},
+	{Total: 9, Up: 7, Count: 3, Action: blackjack.ActionDoubleDown},
+	{Total: 16, Up: 9, Count: 5, Action: blackjack.ActionStand},
+	{Total: 13, Up: 2, Count: -1, Action: blackjack.ActionStand},
+	{Total: 12, Up: 4, Count: 0, Action: blackjack.ActionStand},
+	{Total: 12, Up: 5, Count: -2, Action: blackjack.ActionStand},
+	{Total: 12, Up: 6, Count: -1, Action: blackjack.ActionStand},
+	{Total: 13, Up: 3, Count: -2, Action: blackjack.ActionStand},
+}
+
+// Fab4 are the surrender index plays for Hi-Lo.
+var Fab4 = []Index{
+	{Total: 14, Up: 10, Count: 3, Action: blackjack.ActionSurrender},
+	{Total: 15, Up: 10, Count: 0, Action: blackjack.ActionSurrender},
+	{Total: 15, Up: 9, Count: 2, Action: blackjack.ActionSurrender},
+	{Total: 15, Up: 1, Count: 1, Action: blackjack.ActionSurrender},
+}
+
+// matches reports whether the index is about the cards against the up card.
+func (i Index) matches(cards []deck.Card, up deck.Card) bool {
+	if value(up.Rank) != i.Up {
+		return false
+	}
+
+	if i.Pair > 0 {
+		return len(cards) == 2 && cards[0].Rank == cards[1].Rank && value(cards[0].Rank) == i.Pair
+	}
+	score := blackjack.Evaluate(cards)
+	return score.Total == i.Total && score.Soft == i.Soft
+}
+
+// String formats the index the way ParseIndices reads it.
+func (i Index) String() string {
+	return fmt.Sprintf("%s %s %+g %s", i.hand(), label(i.Up), i.Count, i.Action)
+}
+
+func (i Index) hand() string {
+	switch {
+	case i.Pair > 0:
+		return label(i.Pair) + "," + label(i.Pair)
+	case i.Soft:
+		return "A" + strconv.Itoa(i.Total-11)
+	default:
+		return strconv.Itoa(i.Total)
+	}
+}
+
+// ParseIndices reads an index table, one index per line in the form
+//
+//	<hand> <up card> <count> <action>
+//
+// The hand is a hard total like 16, a soft total like A7 or a pair like 8,8 and the up card is 2 to 10 or A.
+// The action is written like blackjack.Action prints it. Blank lines and everything after a # are ignored.
+func ParseIndices(r io.Reader) ([]Index, error) {
+	var indices []Index
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		i, err := parseIndex(fields)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		indices = append(indices, i)
+	}
+	return indices, scanner.Err()
+}
+
+func parseIndex(fields []string) (Index, error) {
+	if len(fields) < 4 {
+		return Index{}, ErrInvalidIndex
+	}
+
+	var i Index
+	var err error
+	hand := fields[0]
+	switch {
+	case strings.Contains(hand, ","):
+		first, second, _ := strings.Cut(hand, ",")
+		if first != second {
+			return Index{}, ErrInvalidIndex
+		}
+		i.Pair, err = parseValue(first)
+	case strings.HasPrefix(hand, "A"):
+		i.Soft = true
+		i.Total, err = strconv.Atoi(hand[1:])
+		i.Total += 11
+	default:
+		i.Total, err = strconv.Atoi(hand)
+	}
+	if err != nil {
+		return Index{}, ErrInvalidIndex
+	}
+
+	if i.Up, err = parseValue(fields[1]); err != nil {
+		return Index{}, err
+	}
+	if i.Count, err = strconv.ParseFloat(fields[2], 64); err != nil {
+		return Index{}, ErrInvalidIndex
+	}
+
+	name := strings.Join(fields[3:], " ")
+	a := slices.IndexFunc(actions, func(a blackjack.Action) bool { return a.String() == name })
+	if a == -1 {
+		return Index{}, ErrInvalidIndex
+	}
+	i.Action = actions[a]
+	return i, nil
+}
+
+// parseValue parses a card value, A for the ace or 2 to 10.
+func parseValue(s string) (int, error) {
+	if s == "A" {
+		return 1, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < 2 || v > 10 {
+		return 0, ErrInvalidIndex
+	}
+	return v, nil
+}
+
+// TrueCounter provides the true count of the shoe, a counting.Counter does.
+type TrueCounter interface {
+	TrueCount() float64
+}
+
+// Deviations plays basic strategy and deviates from it by the index plays at the true count.
+type Deviations struct {
+	basic   *Strategy
+	counter TrueCounter
+	indices []Index
+}
+
+// NewDeviations creates a pointer to Deviations from the basic strategy with the index plays at the true count of
+// the counter, for example Fab4 and Illustrious18.
+func NewDeviations(basic *Strategy, counter TrueCounter, indices ...Index) *Deviations {
+	return &Deviations{
+		basic:   basic,
+		counter: counter,
+		indices: indices,
+	}
+}
+
+// Action returns the action for the cards against the dealer's up card out of the legal actions. Without legal
+// actions they are derived from the rules for a hand which was not split.
+//
+// Surrender and split are decided first, by their index if there is one and by basic strategy otherwise. An index
+// of another action is only taken if surrendering and splitting were not.
+func (d *Deviations) Action(cards []deck.Card, up deck.Card, legal ...blackjack.Action) blackjack.Action {
+	if len(legal) == 0 {
+		legal = d.basic.legal(cards)
+	}
+	legal = slices.Clone(legal)
+
+	ev := d.basic.EV(cards, up)
+	count := d.counter.TrueCount()
+	decide := func(a blackjack.Action, i Index, ok bool) bool {
+		if !contains(legal, a) {
+			return false
+		}
+		if ok && count >= i.Count {
+			return true
+		}
+		if !ok {
+			if best, _ := ev.Best(legal...); best == a {
+				return true
+			}
+		}
+		legal = slices.DeleteFunc(legal, func(l blackjack.Action) bool { return l == a })
+		return false
+	}
+
+	for _, a := range []blackjack.Action{blackjack.ActionSurrender, blackjack.ActionSplit} {
+		i, ok := d.index(cards, up, a)
+		if decide(a, i, ok) {
+			return a
+		}
+	}
+
+	for _, i := range d.indices {
+		if i.Action == blackjack.ActionSurrender || i.Action == blackjack.ActionSplit || !i.matches(cards, up) {
+			continue
+		}
+		if decide(i.Action, i, true) {
+			return i.Action
+		}
+	}
+
+	best, _ := ev.Best(legal...)
+	return best
+}
+
+// index returns the index of the action for the cards against the up card.
+func (d *Deviations) index(cards []deck.Card, up deck.Card, a blackjack.Action) (Index, bool) {
+	for _, i := range d.indices {
+		if i.Action == a && i.matches(cards, up) {
+			return i, true
+		}
+	}
+	return Index{}, false
+}
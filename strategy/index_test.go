@@ -0,0 +1,164 @@
+package strategy
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/deck"
+)
+
+type fixedCount float64
+
+func (c fixedCount) TrueCount() float64 {
+	return float64(c)
+}
+
+func TestDeviations_Action(t *testing.T) {
+	sixDecks := blackjack.Rules{
+		Decks:            6,
+		DoubleOn:         blackjack.DoubleAnyTwo,
+		DoubleAfterSplit: true,
+		Surrender:        true,
+		Peek:             true,
+	}
+	noSurrender := sixDecks
+	noSurrender.Surrender = false
+
+	indices := append(append([]Index{}, Fab4...), Illustrious18...)
+
+	tests := []struct {
+		name  string
+		rules blackjack.Rules
+		count float64
+		cards []deck.Card
+		up    deck.Rank
+		legal []blackjack.Action
+		want  blackjack.Action
+	}{
+		{name: "16 against ten stands at 0", rules: noSurrender, count: 0, cards: cards(deck.Ten, deck.Six), up: deck.King, want: blackjack.ActionStand},
+		{name: "16 against ten hits below 0", rules: noSurrender, count: -1, cards: cards(deck.Ten, deck.Six), up: deck.King, want: blackjack.ActionHit},
+		{name: "16 against ten surrenders by basic strategy", rules: sixDecks, count: 2, cards: cards(deck.Ten, deck.Six), up: deck.King, want: blackjack.ActionSurrender},
+		{name: "15 against ten surrenders at 0", rules: sixDecks, count: 0, cards: cards(deck.Ten, deck.Five), up: deck.Ten, want: blackjack.ActionSurrender},
+		{name: "15 against ten hits below 0", rules: sixDecks, count: -0.5, cards: cards(deck.Ten, deck.Five), up: deck.Ten, want: blackjack.ActionHit},
+		{name: "14 against ten surrenders at 3", rules: sixDecks, count: 3, cards: cards(deck.Ten, deck.Four), up: deck.Ten, want: blackjack.ActionSurrender},
+		{name: "tens split against 6 at 4", rules: sixDecks, count: 4, cards: cards(deck.King, deck.King), up: deck.Six, want: blackjack.ActionSplit},
+		{name: "tens stand against 6 below 4", rules: sixDecks, count: 3, cards: cards(deck.Ten, deck.Ten), up: deck.Six, want: blackjack.ActionStand},
+		{name: "eights split before the index of 16", rules: noSurrender, count: 1, cards: cards(deck.Eight, deck.Eight), up: deck.Ten, want: blackjack.ActionSplit},
+		{name: "12 against 3 stands at 2", rules: sixDecks, count: 2, cards: cards(deck.Ten, deck.Two), up: deck.Three, want: blackjack.ActionStand},
+		{name: "12 against 3 hits below 2", rules: sixDecks, count: 1, cards: cards(deck.Ten, deck.Two), up: deck.Three, want: blackjack.ActionHit},
+		{name: "13 against 2 hits below -1", rules: sixDecks, count: -2, cards: cards(deck.Ten, deck.Three), up: deck.Two, want: blackjack.ActionHit},
+		{name: "10 against ten doubles at 4", rules: sixDecks, count: 4, cards: cards(deck.Six, deck.Four), up: deck.Queen, want: blackjack.ActionDoubleDown},
+		{
+			name:  "10 against ten hits if doubling is not legal",
+			rules: sixDecks,
+			count: 4,
+			cards: cards(deck.Six, deck.Four),
+			up:    deck.Queen,
+			legal: []blackjack.Action{blackjack.ActionHit, blackjack.ActionStand},
+			want:  blackjack.ActionHit,
+		},
+		{name: "three card 16 against ten stands at 0", rules: sixDecks, count: 0, cards: cards(deck.Two, deck.Four, deck.Ten), up: deck.Ten, want: blackjack.ActionStand},
+		{name: "hands without an index play basic strategy", rules: sixDecks, count: 10, cards: cards(deck.Ten, deck.Seven), up: deck.Ten, want: blackjack.ActionStand},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := NewDeviations(New(test.rules), fixedCount(test.count), indices...)
+
+			got := d.Action(test.cards, deck.Card{Rank: test.up}, test.legal...)
+			if got != test.want {
+				t.Errorf("want %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestParseIndices(t *testing.T) {
+	table := `
+# hand up count action
+16    10  0    stand
+10,10 6   +4   split   # tens against six
+A8    6   1.5  double down
+`
+
+	got, err := ParseIndices(strings.NewReader(table))
+	if err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	want := []Index{
+		{Total: 16, Up: 10, Count: 0, Action: blackjack.ActionStand},
+		{Pair: 10, Up: 6, Count: 4, Action: blackjack.ActionSplit},
+		{Total: 19, Soft: true, Up: 6, Count: 1.5, Action: blackjack.ActionDoubleDown},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+}
+
+func TestParseIndices_RoundTrip(t *testing.T) {
+	var b strings.Builder
+	for _, i := range append(append([]Index{}, Illustrious18...), Fab4...) {
+		b.WriteString(i.String() + "\n")
+	}
+
+	got, err := ParseIndices(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	want := append(append([]Index{}, Illustrious18...), Fab4...)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+}
+
+func TestParseIndices_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{name: "missing action", line: "16 10 0"},
+		{name: "unknown action", line: "16 10 0 insure"},
+		{name: "invalid up card", line: "16 11 0 stand"},
+		{name: "invalid count", line: "16 10 high stand"},
+		{name: "mixed pair", line: "8,9 10 0 split"},
+		{name: "invalid total", line: "sixteen 10 0 stand"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseIndices(strings.NewReader("\n" + test.line))
+			if !errors.Is(err, ErrInvalidIndex) {
+				t.Errorf("want %v, got %v", ErrInvalidIndex, err)
+			}
+			if err != nil && !strings.HasPrefix(err.Error(), "line 2:") {
+				t.Errorf("want the line number in %q", err)
+			}
+		})
+	}
+}
+
+func TestIndex_String(t *testing.T) {
+	tests := []struct {
+		index Index
+		want  string
+	}{
+		{index: Illustrious18[0], want: "16 10 +0 stand"},
+		{index: Illustrious18[2], want: "10,10 5 +5 split"},
+		{index: Illustrious18[7], want: "11 A +1 double down"},
+		{index: Illustrious18[12], want: "13 2 -1 stand"},
+		{index: Index{Total: 18, Soft: true, Up: 2, Count: 1, Action: blackjack.ActionDoubleDown}, want: "A7 2 +1 double down"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			if got := test.index.String(); got != test.want {
+				t.Errorf("want %#v, got %#v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Package strategy computes basic strategy for the rules of a blackjack.Table. Instead of shipping fixed charts it
+// derives every decision from the expected values of the actions, so any combination of deck count, soft 17 rule,
+// double and split rules, surrender and peek gets its own chart. Deviations adds the index plays of a card counter
+// on top of it.
+//
+// Rules which change the game beyond that, like the bonuses of Spanish 21 or the free bets of Free Bet Blackjack,
+// are not taken into account.
+package strategy
+
+import (
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/deck"
+)
+
+// Strategy decides on actions using the chart generated for the rules.
+type Strategy struct {
+	rules blackjack.Rules
+	chart *Chart
+}
+
+// New creates a pointer to a Strategy for the rules, usually the ones a Table was created with.
+func New(rules blackjack.Rules) *Strategy {
+	return &Strategy{
+		rules: rules,
+		chart: NewChart(rules),
+	}
+}
+
+// Chart returns the chart the strategy decides with.
+func (s *Strategy) Chart() *Chart {
+	return s.chart
+}
+
+// EV returns the expected values of the actions for the cards against the dealer's up card.
+// A busted hand has no actions.
+func (s *Strategy) EV(cards []deck.Card, up deck.Card) EV {
+	if len(cards) == 2 && cards[0].Rank == cards[1].Rank {
+		return s.chart.Pair(cards[0].Rank, up.Rank)
+	}
+
+	score := blackjack.Evaluate(cards)
+	if score.Soft {
+		return s.chart.Soft(score.Total, up.Rank)
+	}
+	return s.chart.Hard(score.Total, up.Rank)
+}
+
+// Action returns the basic strategy action for the cards against the dealer's up card out of the legal actions.
+// Without legal actions they are derived from the rules for a hand which was not split.
+func (s *Strategy) Action(cards []deck.Card, up deck.Card, legal ...blackjack.Action) blackjack.Action {
+	if len(legal) == 0 {
+		legal = s.legal(cards)
+	}
+
+	action, _ := s.EV(cards, up).Best(legal...)
+	return action
+}
+
+// Advise returns the best of the legal actions for the cards against the dealer's up card and the expected
+// value of every legal action. It makes a Strategy the blackjack.Coach of a Table in trainer mode.
+func (s *Strategy) Advise(cards []deck.Card, up deck.Card, legal []blackjack.Action) (blackjack.Action, map[blackjack.Action]float64) {
+	ev := s.EV(cards, up)
+	best, _ := ev.Best(legal...)
+
+	values := make(map[blackjack.Action]float64, len(legal))
+	for _, a := range legal {
+		if v, ok := ev[a]; ok {
+			values[a] = v
+		}
+	}
+	return best, values
+}
+
+// legal returns the actions the rules allow on the cards of a hand which was not split.
+func (s *Strategy) legal(cards []deck.Card) []blackjack.Action {
+	legal := []blackjack.Action{blackjack.ActionHit, blackjack.ActionStand}
+
+	score := blackjack.Evaluate(cards)
+	if len(cards) == 2 && canDouble(s.rules.DoubleOn, score.Total) || s.rules.DoubleOn == blackjack.DoubleAny {
+		legal = append(legal, blackjack.ActionDoubleDown)
+	}
+	if len(cards) == 2 && cards[0].Rank == cards[1].Rank {
+		legal = append(legal, blackjack.ActionSplit)
+	}
+	if len(cards) == 2 && s.rules.Surrender {
+		legal = append(legal, blackjack.ActionSurrender)
+	}
+	return legal
+}
@@ -0,0 +1,126 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/Hydoc/blackjack"
+	"github.com/Hydoc/deck"
+)
+
+func TestStrategy_Action(t *testing.T) {
+	sixDecks := blackjack.Rules{
+		Decks:            6,
+		DoubleOn:         blackjack.DoubleAnyTwo,
+		DoubleAfterSplit: true,
+		Surrender:        true,
+		Peek:             true,
+	}
+	hitSoft17 := sixDecks
+	hitSoft17.DealerHitsSoft17 = true
+	noSurrender := sixDecks
+	noSurrender.Surrender = false
+	noDoubleAfterSplit := sixDecks
+	noDoubleAfterSplit.DoubleAfterSplit = false
+
+	tests := []struct {
+		name  string
+		rules blackjack.Rules
+		cards []deck.Card
+		up    deck.Rank
+		legal []blackjack.Action
+		want  blackjack.Action
+	}{
+		{name: "hard 12 against 2 hits", rules: sixDecks, cards: cards(deck.Ten, deck.Two), up: deck.Two, want: blackjack.ActionHit},
+		{name: "hard 12 against 4 stands", rules: sixDecks, cards: cards(deck.Ten, deck.Two), up: deck.Four, want: blackjack.ActionStand},
+		{name: "hard 11 against 6 doubles", rules: sixDecks, cards: cards(deck.Six, deck.Five), up: deck.Six, want: blackjack.ActionDoubleDown},
+		{name: "hard 11 against ace hits on S17", rules: sixDecks, cards: cards(deck.Six, deck.Five), up: deck.Ace, want: blackjack.ActionHit},
+		{name: "hard 11 against ace doubles on H17", rules: hitSoft17, cards: cards(deck.Six, deck.Five), up: deck.Ace, want: blackjack.ActionDoubleDown},
+		{name: "hard 16 against ten surrenders", rules: sixDecks, cards: cards(deck.Ten, deck.Six), up: deck.King, want: blackjack.ActionSurrender},
+		{name: "hard 16 against ten hits without surrender", rules: noSurrender, cards: cards(deck.Ten, deck.Six), up: deck.King, want: blackjack.ActionHit},
+		{name: "hard 17 against ace surrenders on H17", rules: hitSoft17, cards: cards(deck.Ten, deck.Seven), up: deck.Ace, want: blackjack.ActionSurrender},
+		{name: "soft 18 against 2 stands on S17", rules: sixDecks, cards: cards(deck.Ace, deck.Seven), up: deck.Two, want: blackjack.ActionStand},
+		{name: "soft 18 against 2 doubles on H17", rules: hitSoft17, cards: cards(deck.Ace, deck.Seven), up: deck.Two, want: blackjack.ActionDoubleDown},
+		{name: "soft 18 against 9 hits", rules: sixDecks, cards: cards(deck.Ace, deck.Seven), up: deck.Nine, want: blackjack.ActionHit},
+		{name: "aces split", rules: sixDecks, cards: cards(deck.Ace, deck.Ace), up: deck.Ten, want: blackjack.ActionSplit},
+		{name: "eights split", rules: sixDecks, cards: cards(deck.Eight, deck.Eight), up: deck.Ten, want: blackjack.ActionSplit},
+		{name: "tens stand", rules: sixDecks, cards: cards(deck.King, deck.King), up: deck.Six, want: blackjack.ActionStand},
+		{name: "fours split against 5 with double after split", rules: sixDecks, cards: cards(deck.Four, deck.Four), up: deck.Five, want: blackjack.ActionSplit},
+		{name: "fours hit against 5 without double after split", rules: noDoubleAfterSplit, cards: cards(deck.Four, deck.Four), up: deck.Five, want: blackjack.ActionHit},
+		{name: "hard 11 against 10 hits without peek", rules: blackjack.DefaultRules(), cards: cards(deck.Six, deck.Five), up: deck.Ten, want: blackjack.ActionHit},
+		{name: "three card 11 can not double", rules: sixDecks, cards: cards(deck.Two, deck.Four, deck.Five), up: deck.Six, want: blackjack.ActionHit},
+		{
+			name:  "double down falls back to hit",
+			rules: sixDecks,
+			cards: cards(deck.Six, deck.Five),
+			up:    deck.Six,
+			legal: []blackjack.Action{blackjack.ActionHit, blackjack.ActionStand},
+			want:  blackjack.ActionHit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(tt.rules)
+
+			if got := s.Action(tt.cards, deck.Card{Rank: tt.up, Suit: deck.Club}, tt.legal...); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestStrategy_EV(t *testing.T) {
+	s := New(blackjack.Rules{Decks: 6, Peek: true})
+
+	tests := []struct {
+		name  string
+		cards []deck.Card
+		want  []blackjack.Action
+	}{
+		{name: "pair can be split", cards: cards(deck.Eight, deck.Eight), want: []blackjack.Action{blackjack.ActionStand, blackjack.ActionHit, blackjack.ActionSplit}},
+		{name: "hard 10 can be doubled", cards: cards(deck.Six, deck.Four), want: []blackjack.Action{blackjack.ActionStand, blackjack.ActionHit, blackjack.ActionDoubleDown}},
+		{name: "hard 17", cards: cards(deck.Ten, deck.Seven), want: []blackjack.Action{blackjack.ActionStand, blackjack.ActionHit}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev := s.EV(tt.cards, deck.Card{Rank: deck.Six})
+
+			if len(ev) != len(tt.want) {
+				t.Errorf("want %v, got %v", tt.want, ev)
+			}
+			for _, a := range tt.want {
+				if _, ok := ev[a]; !ok {
+					t.Errorf("want %s in %v", a, ev)
+				}
+			}
+		})
+	}
+
+	if ev := s.EV(cards(deck.Ten, deck.Six, deck.Nine), deck.Card{Rank: deck.Six}); ev != nil {
+		t.Errorf("want nil, got %v", ev)
+	}
+}
+
+func cards(ranks ...deck.Rank) []deck.Card {
+	out := make([]deck.Card, len(ranks))
+	for i, r := range ranks {
+		out[i] = deck.Card{Rank: r, Suit: deck.Spade}
+	}
+	return out
+}
+
+func TestStrategy_Advise(t *testing.T) {
+	var coach blackjack.Coach = New(blackjack.Rules{Decks: 6, Peek: true})
+
+	legal := []blackjack.Action{blackjack.ActionHit, blackjack.ActionStand}
+	best, ev := coach.Advise(cards(deck.Six, deck.Five), deck.Card{Rank: deck.Six}, legal)
+
+	if best != blackjack.ActionHit {
+		t.Errorf("want %s, got %s", blackjack.ActionHit, best)
+	}
+
+	if len(ev) != 2 || ev[blackjack.ActionHit] <= ev[blackjack.ActionStand] {
+		t.Errorf("want hit to be better than stand, got %v", ev)
+	}
+}
@@ -0,0 +1,19 @@
+package blackjack
+
+// SwitchRules returns the rules of Blackjack Switch. Every player plays two hands with equal bets and may swap
+// their second cards. Black jack pays even money and a dealer 22 pushes against every hand which is not busted.
+// Doubling is allowed on any first two cards. Split hands are not supported, every player plays exactly two hands.
+func SwitchRules() Rules {
+	return Rules{
+		Decks:            6,
+		Switch:           true,
+		DealerPushesOn22: true,
+		BlackJackPayout:  EvenMoney,
+		DoubleOn:         DoubleAnyTwo,
+	}
+}
+
+// NewBlackjackSwitch creates a pointer to a Table playing SwitchRules. Options are applied on top of the variant.
+func NewBlackjackSwitch(opts ...func(t *Table) *Table) *Table {
+	return New(append([]func(t *Table) *Table{WithRules(SwitchRules())}, opts...)...)
+}
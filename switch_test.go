@@ -0,0 +1,208 @@
+package blackjack
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Hydoc/deck"
+)
+
+func TestNewBlackjackSwitch(t *testing.T) {
+	table := NewBlackjackSwitch(WithBetLimits(5*Unit, 0))
+
+	if !table.rules.Switch || !table.rules.DealerPushesOn22 || table.rules.MinBet != 5*Unit {
+		t.Errorf("want Blackjack Switch rules with limits, got %#v", table.rules)
+	}
+}
+
+func TestBlackjackSwitch_Bet(t *testing.T) {
+	table := NewBlackjackSwitch()
+	player := NewPlayer(100 * Unit)
+	table.Join(player)
+
+	if err := table.Bet(player, 60*Unit); !errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("want %v, got %v", ErrInsufficientFunds, err)
+	}
+
+	if err := table.Bet(player, 10*Unit); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	if got := player.Balance(); got != 80*Unit {
+		t.Errorf("want %s, got %s", 80*Unit, got)
+	}
+
+	if player.hands.first.bet != 10*Unit || player.hands.second.bet != 10*Unit {
+		t.Errorf("want two bets of %s, got %#v", 10*Unit, player.hands)
+	}
+}
+
+func TestBlackjackSwitch_Switch(t *testing.T) {
+	table := NewBlackjackSwitch()
+	table.deck = stack(
+		deck.Card{Rank: deck.Ace, Suit: deck.Club},
+		deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+		deck.Card{Rank: deck.Nine, Suit: deck.Club},
+		deck.Card{Rank: deck.Seven, Suit: deck.Heart},
+		deck.Card{Rank: deck.King, Suit: deck.Spade},
+		deck.Card{Rank: deck.Nine, Suit: deck.Diamond},
+	)
+	player := NewPlayer(100 * Unit)
+	table.Join(player)
+	table.Bet(player, 10*Unit)
+
+	if err := table.Start(); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	if err := table.Switch(); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	first, second := player.hands.first, player.hands.second
+	if !first.hasBlackJack() || second.sum() != 17 {
+		t.Errorf("want black jack and 17, got %#v and %#v", first.cards, second.cards)
+	}
+
+	if err := table.Switch(); !errors.Is(err, ErrNotAllowed) {
+		t.Errorf("want %v, got %v", ErrNotAllowed, err)
+	}
+
+	table.Stand()
+	table.Stand()
+
+	// a switched 21 is no black jack and wins against 18, 17 loses
+	if got := player.Balance(); got != 100*Unit {
+		t.Errorf("want %s, got %s", 100*Unit, got)
+	}
+}
+
+func TestBlackjackSwitch_Settle(t *testing.T) {
+	tests := []struct {
+		name        string
+		deck        []deck.Card
+		wantBalance Money
+	}{
+		{
+			name: "dealer 22 pushes",
+			deck: stack(
+				deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+				deck.Card{Rank: deck.Ten, Suit: deck.Club},
+				deck.Card{Rank: deck.Six, Suit: deck.Club},
+				deck.Card{Rank: deck.Nine, Suit: deck.Heart},
+				deck.Card{Rank: deck.Eight, Suit: deck.Spade},
+				deck.Card{Rank: deck.Six, Suit: deck.Diamond},
+				deck.Card{Rank: deck.King, Suit: deck.Diamond},
+			),
+			wantBalance: 100 * Unit,
+		},
+		{
+			name: "dealer 23 busts",
+			deck: stack(
+				deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+				deck.Card{Rank: deck.Ten, Suit: deck.Club},
+				deck.Card{Rank: deck.Seven, Suit: deck.Club},
+				deck.Card{Rank: deck.Nine, Suit: deck.Heart},
+				deck.Card{Rank: deck.Eight, Suit: deck.Spade},
+				deck.Card{Rank: deck.Six, Suit: deck.Diamond},
+				deck.Card{Rank: deck.King, Suit: deck.Diamond},
+			),
+			wantBalance: 120 * Unit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewBlackjackSwitch()
+			table.deck = tt.deck
+			player := NewPlayer(100 * Unit)
+			table.Join(player)
+			table.Bet(player, 10*Unit)
+
+			if err := table.Start(); err != nil {
+				t.Fatalf("want nil, got %v", err)
+			}
+			table.Stand()
+			table.Stand()
+
+			if got := player.Balance(); got != tt.wantBalance {
+				t.Errorf("want %s, got %s", tt.wantBalance, got)
+			}
+		})
+	}
+}
+
+func TestBlackjackSwitch_SettleSwitched(t *testing.T) {
+	tests := []struct {
+		name        string
+		deck        []deck.Card
+		wantBalance Money
+	}{
+		{
+			name: "switched 21 pushes against dealer 22",
+			deck: stack(
+				deck.Card{Rank: deck.Ace, Suit: deck.Club},
+				deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+				deck.Card{Rank: deck.Six, Suit: deck.Club},
+				deck.Card{Rank: deck.Seven, Suit: deck.Heart},
+				deck.Card{Rank: deck.King, Suit: deck.Spade},
+				deck.Card{Rank: deck.Six, Suit: deck.Diamond},
+				deck.Card{Rank: deck.Ten, Suit: deck.Diamond},
+			),
+			wantBalance: 100 * Unit,
+		},
+		{
+			name: "switched 21 loses against dealer black jack",
+			deck: stack(
+				deck.Card{Rank: deck.Ace, Suit: deck.Club},
+				deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+				deck.Card{Rank: deck.Ace, Suit: deck.Diamond},
+				deck.Card{Rank: deck.Seven, Suit: deck.Heart},
+				deck.Card{Rank: deck.King, Suit: deck.Spade},
+				deck.Card{Rank: deck.King, Suit: deck.Diamond},
+			),
+			wantBalance: 80 * Unit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewBlackjackSwitch()
+			table.deck = tt.deck
+			player := NewPlayer(100 * Unit)
+			table.Join(player)
+			table.Bet(player, 10*Unit)
+
+			if err := table.Start(); err != nil {
+				t.Fatalf("want nil, got %v", err)
+			}
+			if err := table.Switch(); err != nil {
+				t.Fatalf("want nil, got %v", err)
+			}
+			table.Stand()
+			table.Stand()
+
+			if got := player.Balance(); got != tt.wantBalance {
+				t.Errorf("want %s, got %s", tt.wantBalance, got)
+			}
+		})
+	}
+}
+
+func TestTable_SwitchNotAllowed(t *testing.T) {
+	table := New()
+	table.deck = stack(
+		deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+		deck.Card{Rank: deck.Six, Suit: deck.Club},
+		deck.Card{Rank: deck.Nine, Suit: deck.Heart},
+		deck.Card{Rank: deck.Seven, Suit: deck.Spade},
+	)
+	player := NewPlayer(100 * Unit)
+	table.Join(player)
+	table.Bet(player, 10*Unit)
+	table.Start()
+
+	if err := table.Switch(); !errors.Is(err, ErrNotAllowed) {
+		t.Errorf("want %v, got %v", ErrNotAllowed, err)
+	}
+}
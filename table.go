@@ -2,6 +2,8 @@ package blackjack
 
 import (
 	"errors"
+	"math/rand/v2"
+	"slices"
 	"sync"
 
 	"github.com/Hydoc/deck"
@@ -15,8 +17,10 @@ const (
 )
 
 var (
-	ErrTableFull    = errors.New("table is full")
-	ErrNoTurnPlayer = errors.New("no turn player")
+	ErrTableFull       = errors.New("table is full")
+	ErrNoTurnPlayer    = errors.New("no turn player")
+	ErrNotAtTable      = errors.New("player is not at the table")
+	ErrRoundInProgress = errors.New("round in progress")
 )
 
 // Table represents a blackjack table. It holds everything relevant for the game.
@@ -24,47 +28,159 @@ var (
 type Table struct {
 	mu sync.Mutex
 
-	gameState  GameState
-	dealer     *Dealer
-	players    [7]*Player
-	deck       []deck.Card
-	turnPlayer *Player
+	gameState   GameState
+	rules       Rules
+	sideBets    []SideBet
+	round       int
+	ledger      *Ledger
+	cutCard     int
+	rand        *rand.Rand
+	trainer     *trainer
+	observers   []Observer
+	reshuffled  *reshuffle
+	bots        map[*Player]Bot
+	botsPlaying bool
+	dealer      *Dealer
+	players     [7]*Player
+	deck        []deck.Card
+	turnPlayer  *Player
 }
 
 type State struct {
-	GameState  GameState
-	Dealer     *Dealer
-	Players    [7]*Player
-	TurnPlayer *Player
+	GameState GameState
+	Rules     Rules
+	Round     int
+	Dealer    *Dealer
+	// DealerCards are the dealer's cards the players can see. While the round is in progress this is only
+	// the up card, unless the rules deal the dealer's cards exposed or hidden.
+	DealerCards []deck.Card
+	Players     [7]*Player
+	TurnPlayer  *Player
+}
+
+// Bet places the bet for the next round of the given player. The amount is taken from the player's wallet.
+// At Blackjack Switch the amount is bet on both hands. A bet placed earlier for the same round is replaced,
+// it is credited back together with its side bets.
+// It returns ErrRoundInProgress while players are still playing, ErrNotAtTable if the player did not join,
+// ErrBetBelowMinimum or ErrBetAboveMaximum if the amount is outside the table limits and
+// ErrInsufficientFunds if the wallet can not cover it. A rejected bet leaves the earlier bet in place.
+func (t *Table) Bet(p *Player, amount Money) error {
+	if t.turnPlayer != nil {
+		return ErrRoundInProgress
+	}
+
+	if !t.isSeated(p) {
+		return ErrNotAtTable
+	}
+
+	if amount <= 0 {
+		return ErrNotAllowed
+	}
+
+	// the balance is checked before the table's chips, which are expensive to check for huge amounts
+	available := p.Balance() + p.pending()
+	if amount > available || t.rules.Switch && amount > available/2 {
+		return ErrInsufficientFunds
+	}
+
+	if err := t.rules.checkBet(amount); err != nil {
+		return err
+	}
+
+	p.round = t.round
+	if err := p.refund(); err != nil {
+		return err
+	}
+	if t.rules.Switch {
+		return p.betTwoHands(amount)
+	}
+	return p.bet(amount)
+}
+
+// PlaceSideBet places the side bet with the given name for the next round of the player next to the main bet,
+// which has to be placed using Bet first. The amount is taken from the player's wallet.
+// It returns ErrUnknownSideBet if the table does not offer the side bet.
+func (t *Table) PlaceSideBet(p *Player, name string, amount Money) error {
+	if t.turnPlayer != nil {
+		return ErrRoundInProgress
+	}
+
+	if !t.isSeated(p) {
+		return ErrNotAtTable
+	}
+
+	i := slices.IndexFunc(t.sideBets, func(bet SideBet) bool { return bet.Name() == name })
+	if i == -1 {
+		return ErrUnknownSideBet
+	}
+
+	p.round = t.round
+	return p.placeSideBet(t.sideBets[i], amount)
+}
+
+// SideBets returns the side bets offered at the table.
+func (t *Table) SideBets() []SideBet {
+	return slices.Clone(t.sideBets)
 }
 
 // Start starts the round at the table by dealing everyone two cards.
+// Side bets with the Timing AfterDeal are settled right after dealing, the others when the round is finished.
 // After dealing the cards it checks if any of the players has black jack and sets the gameState
 // which can be checked using either InProgress or IsDone.
-func (t *Table) Start() {
+// If nobody is left to play, or the dealer peeked at a black jack, the round is finished right away.
+// Players bound to a Bot play their hands right away when it is their turn.
+// Cards of a previous round are cleared before dealing. It returns ErrRoundInProgress while players are still playing.
+func (t *Table) Start() error {
+	if t.turnPlayer != nil {
+		return ErrRoundInProgress
+	}
+
+	if len(t.dealer.hand.cards) > 0 {
+		t.dealer = newDealer()
+	}
+
+	for _, p := range t.players {
+		if p == nil {
+			continue
+		}
+		if len(p.hands.first.cards) > 0 {
+			p.hands = newHands()
+		}
+		p.round = t.round
+	}
+
 	for range 2 {
 		for _, p := range t.players {
 			if p == nil {
 				continue
 			}
-			card := t.drawCard()
-			p.Hit(card)
+			for _, h := range p.hands.all() {
+				h.hit(t.drawCard())
+			}
 		}
 
-		card := t.drawCard()
+		card := t.drawFaceDown()
+		if len(t.dealer.hand.cards) < t.rules.faceUp() {
+			t.show(card)
+		}
 		t.dealer.hit(card)
 	}
 
+	err := t.settleSideBets(AfterDeal)
+
+	if t.rules.Peek && t.dealer.hand.hasBlackJack() {
+		return errors.Join(err, t.finish())
+	}
+
 	for _, p := range t.players {
-		if p != nil && !p.hasBlackJack() {
+		if p != nil && (p.hands.mode == twoHands || !p.hasBlackJack()) {
 			t.turnPlayer = p
 			t.gameState = inProgress
-			return
+			return errors.Join(err, t.playBots())
 		}
 	}
 
-	t.turnPlayer = nil
-	t.gameState = done
+	return errors.Join(err, t.finish())
 }
 
 // InProgress returns a bool whether the gameState is inProgress.
@@ -77,53 +193,300 @@ func (t *Table) IsDone() bool {
 	return t.gameState == done
 }
 
-// Hit lets the turnPlayer hit a card. If the player busts after hitting with the current active hand it calls stand
-// automatically and changes, in case of a split, to the other hand. If there is no more hand to be played the
-// next player will be the turnPlayer.
+// Hit lets the turnPlayer hit a card. A doubled hand can not be hit, a bought one can. If the player busts after hitting
+// with the current active hand, or completes a five-card trick or a Charlie, it calls stand automatically and changes,
+// in case of a split, to the other hand. If there is no more hand to be played the next player will be the turnPlayer.
 func (t *Table) Hit() error {
 	if t.turnPlayer == nil {
 		return ErrNoTurnPlayer
 	}
 
+	t.review(ActionHit)
+
+	if t.turnPlayer.hands.active.doubles > 0 && !t.rules.Buy {
+		return ErrNotAllowed
+	}
+
 	t.turnPlayer.Hit(t.drawCard())
 
-	if t.turnPlayer.busted() {
+	if t.turnPlayer.busted() || t.hasFiveCardTrick() || t.rules.isCharlie(t.turnPlayer.hands.active) {
 		t.turnPlayer.Stand()
 
-		t.nextIfDone()
+		return t.nextIfDone()
 	}
 	return nil
 }
 
 // Stand calls stand on the current turnPlayer and switches to the next hand in case of a split. If there is no more
 // hand to be played the next player will be the turnPlayer.
+// It returns ErrNotAllowed if the total is below the minimum the house rules allow to stand on.
 func (t *Table) Stand() error {
 	if t.turnPlayer == nil {
 		return ErrNoTurnPlayer
 	}
 
+	t.review(ActionStand)
+
+	if t.turnPlayer.hands.active.sum() < t.rules.MinStand {
+		return ErrNotAllowed
+	}
+
 	t.turnPlayer.Stand()
-	t.nextIfDone()
+	return t.nextIfDone()
+}
 
+// DoubleDown doubles the bet of the turnPlayer's active hand, deals exactly one more card and stands.
+// If the house rules allow redoubling the hand stays active until it is not doubled again.
+// If the house rules allow doubling for less and the wallet can not cover the whole bet, it doubles for the balance.
+// The additional wager must be within the table limits.
+func (t *Table) DoubleDown() error {
+	if t.turnPlayer == nil {
+		return ErrNoTurnPlayer
+	}
+
+	t.review(ActionDoubleDown)
+
+	if t.canDoubleDownFree() {
+		t.turnPlayer.hands.doubleDownFree(t.drawCard())
+		t.turnPlayer.Stand()
+		return t.nextIfDone()
+	}
+
+	return t.doubleDown(t.doubleAmount())
+}
+
+// doubleAmount returns what doubling the turnPlayer's active hand costs. It is the whole stake, with DoubleForLess
+// as much of it as the wallet covers.
+func (t *Table) doubleAmount() Money {
+	stake := t.turnPlayer.hands.active.stake()
+	if t.rules.DoubleForLess {
+		return min(stake, t.turnPlayer.wallet.Balance())
+	}
+	return stake
+}
+
+// DoubleDownFor doubles the turnPlayer's active hand for less than the original bet, deals exactly one more card
+// and stands. The house rules have to allow doubling for less, otherwise the amount must match the original bet.
+// The additional wager must be within the table limits.
+func (t *Table) DoubleDownFor(amount Money) error {
+	if t.turnPlayer == nil {
+		return ErrNoTurnPlayer
+	}
+
+	if !t.rules.DoubleForLess && amount != t.turnPlayer.hands.active.stake() {
+		return ErrNotAllowed
+	}
+
+	if t.canDoubleDown(amount) && t.rules.checkBet(amount) == nil {
+		t.review(ActionDoubleDown)
+	}
+
+	return t.doubleDown(amount)
+}
+
+func (t *Table) doubleDown(amount Money) error {
+	if !t.canDoubleDown(amount) {
+		return ErrNotAllowed
+	}
+
+	if err := t.rules.checkBet(amount); err != nil {
+		return err
+	}
+
+	err := t.turnPlayer.doubleDown(t.drawCard(), amount)
+	if err != nil {
+		return err
+	}
+
+	if h := t.turnPlayer.hands.active; h.busted() || h.doubles > t.rules.Redouble || t.rules.isCharlie(h) {
+		t.turnPlayer.Stand()
+		return t.nextIfDone()
+	}
 	return nil
 }
 
-func (t *Table) DoubleDown() error {
+// canDoubleDown reports whether the turnPlayer may double the active hand for the amount under the house rules.
+// Redoubling is allowed on any amount of cards.
+func (t *Table) canDoubleDown(amount Money) bool {
+	p := t.turnPlayer
+	h := p.hands.active
+
+	if t.rules.Buy || p.hands.mode == split && !t.rules.DoubleAfterSplit {
+		return false
+	}
+
+	rule := t.rules.DoubleOn
+	if h.doubles > 0 {
+		rule = DoubleAny
+	}
+
+	return h.doubles <= t.rules.Redouble &&
+		amount > 0 &&
+		amount <= h.stake() &&
+		amount <= p.wallet.Balance() &&
+		h.canDoubleDownOn(rule)
+}
+
+// canDoubleDownFree reports whether the house pays the turnPlayer's double under the Free Bet rules.
+// Only the first two cards of a hard 9, 10 or 11 are doubled for free.
+func (t *Table) canDoubleDownFree() bool {
+	h := t.turnPlayer.hands.active
+	if !t.rules.FreeDoubles || h.doubles > 0 || Evaluate(h.cards).Soft {
+		return false
+	}
+
+	if t.turnPlayer.hands.mode == split && !t.rules.DoubleAfterSplit {
+		return false
+	}
+
+	return h.canDoubleDownOn(DoubleNineToEleven)
+}
+
+// Buy adds up to the original bet to the turnPlayer's active hand and deals one more card. Unlike doubling
+// the hand stays active and can buy again or twist. A hand can not buy after it twisted, nor buy a fifth card.
+// The additional wager must be within the table limits.
+func (t *Table) Buy(amount Money) error {
+	if t.turnPlayer == nil {
+		return ErrNoTurnPlayer
+	}
+
+	if !t.rules.Buy {
+		return ErrNotAllowed
+	}
+
+	p := t.turnPlayer
+	h := p.hands.active
+	if len(h.cards) != 2+h.doubles || len(h.cards) >= 4 || amount <= 0 || amount > h.bet-h.doubled {
+		return ErrNotAllowed
+	}
+
+	if err := t.rules.checkBet(amount); err != nil {
+		return err
+	}
+
+	if err := p.doubleDown(t.drawCard(), amount); err != nil {
+		return err
+	}
+
+	if p.busted() {
+		p.Stand()
+		return t.nextIfDone()
+	}
+	return nil
+}
+
+// hasFiveCardTrick reports whether the turnPlayer's active hand completed a five-card trick under the house rules.
+func (t *Table) hasFiveCardTrick() bool {
+	return t.rules.FiveCardTrick && len(t.turnPlayer.hands.active.cards) >= 5
+}
+
+// Split splits the turnPlayer's pair into two hands with the same bet each and deals the first of them its second card.
+// The second hand gets its second card once the first hand is finished. Split hands can not be split again.
+// The additional wager must be within the table limits.
+func (t *Table) Split() error {
 	if t.turnPlayer == nil {
 		return ErrNoTurnPlayer
 	}
 
-	err := t.turnPlayer.DoubleDown(t.drawCard())
+	t.review(ActionSplit)
+
+	if t.canSplitFree() {
+		if err := t.turnPlayer.splitFree(); err != nil {
+			return err
+		}
+		t.dealSecondCard()
+		return nil
+	}
+
+	if !t.turnPlayer.canSplit() {
+		return ErrNotAllowed
+	}
+
+	if err := t.rules.checkBet(t.turnPlayer.hands.active.bet); err != nil {
+		return err
+	}
+
+	err := t.turnPlayer.Split()
 	if err != nil {
 		return err
 	}
 
-	t.turnPlayer.Stand()
-	t.nextIfDone()
+	t.dealSecondCard()
 
 	return nil
 }
 
+// canSplitFree reports whether the house pays the turnPlayer's split under the Free Bet rules.
+// Every pair except for tens is split for free.
+func (t *Table) canSplitFree() bool {
+	h := t.turnPlayer.hands
+	return t.rules.FreeSplits && h.canSplit() && value(h.active.cards[0]) != 10
+}
+
+// Switch swaps the second cards of the turnPlayer's two hands at Blackjack Switch.
+// It is only allowed before the first hand is played.
+func (t *Table) Switch() error {
+	if t.turnPlayer == nil {
+		return ErrNoTurnPlayer
+	}
+
+	if !t.rules.Switch {
+		return ErrNotAllowed
+	}
+
+	return t.turnPlayer.Switch()
+}
+
+// Surrender gives up the turnPlayer's hand for half of the bet. It is only allowed on the first two cards
+// without a split and if the house rules allow surrender. The surrender is late, against a dealer black jack
+// the whole bet is lost.
+func (t *Table) Surrender() error {
+	if t.turnPlayer == nil {
+		return ErrNoTurnPlayer
+	}
+
+	t.review(ActionSurrender)
+
+	if !t.rules.Surrender {
+		return ErrNotAllowed
+	}
+
+	err := t.turnPlayer.Surrender()
+	if err != nil {
+		return err
+	}
+
+	return t.nextIfDone()
+}
+
+// LegalActions returns the actions the turnPlayer can take on the active hand. Buy is not part of them.
+func (t *Table) LegalActions() []Action {
+	if t.turnPlayer == nil {
+		return nil
+	}
+
+	p := t.turnPlayer
+	h := p.hands.active
+
+	legal := make([]Action, 0, 5)
+	if h.doubles == 0 || t.rules.Buy {
+		legal = append(legal, ActionHit)
+	}
+	if h.sum() >= t.rules.MinStand {
+		legal = append(legal, ActionStand)
+	}
+	if amount := t.doubleAmount(); t.canDoubleDownFree() || t.canDoubleDown(amount) && t.rules.checkBet(amount) == nil {
+		legal = append(legal, ActionDoubleDown)
+	}
+	if t.canSplitFree() || p.canSplit() && t.rules.checkBet(h.bet) == nil {
+		legal = append(legal, ActionSplit)
+	}
+	if t.rules.Surrender && p.hands.canSurrender() {
+		legal = append(legal, ActionSurrender)
+	}
+	return legal
+}
+
 // Join adds a player to the nextIfDone nil value in the players slice.
 // It returns ErrTableFull when there is no space left.
 func (t *Table) Join(p *Player) error {
@@ -133,45 +496,240 @@ func (t *Table) Join(p *Player) error {
 	for i := range t.players {
 		if t.players[i] == nil {
 			t.players[i] = p
+			p.ledger = t.ledger
 			return nil
 		}
 	}
 	return ErrTableFull
 }
 
-// Leave removes a player from the table if it was found. It does nothing otherwise
-func (t *Table) Leave(p *Player) {
+// Leave removes a player from the table if it was found, together with the binding to a Bot. It does nothing otherwise.
+// Bets and side bets placed for a round which was not dealt yet are credited back.
+func (t *Table) Leave(p *Player) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
 	for i := range t.players {
 		if t.players[i] == p {
 			t.players[i] = nil
-			return
+			delete(t.bots, p)
+			return p.refund()
 		}
 	}
+	return nil
+}
+
+// Ledger returns the ledger of every debit and credit made by the players at the table.
+// It is nil if the table was created WithLedger(nil).
+func (t *Table) Ledger() *Ledger {
+	return t.ledger
+}
+
+// UpCard returns the dealer's up card. There is none before the first deal and when the rules deal the dealer's
+// cards hidden.
+func (t *Table) UpCard() (deck.Card, bool) {
+	if len(t.dealer.hand.cards) == 0 || t.rules.DealerHidden && t.gameState == inProgress {
+		return deck.Card{}, false
+	}
+	return t.dealer.hand.cards[0], true
 }
 
 func (t *Table) State() State {
 	return State{
-		GameState:  t.gameState,
-		Dealer:     t.dealer,
-		Players:    t.players,
-		TurnPlayer: t.turnPlayer,
+		GameState:   t.gameState,
+		Rules:       t.rules,
+		Round:       t.round,
+		Dealer:      t.dealer,
+		DealerCards: t.dealerCards(),
+		Players:     t.players,
+		TurnPlayer:  t.turnPlayer,
 	}
 }
 
-// changes the turnPlayer to the next one if the turnPlayer isDone (if no more hand is to be played)
-func (t *Table) nextIfDone() {
+// dealerCards returns a copy of the dealer's cards which are face up.
+func (t *Table) dealerCards() []deck.Card {
+	cards := t.dealer.Cards()
+	if t.gameState == inProgress {
+		cards = cards[:min(t.rules.faceUp(), len(cards))]
+	}
+	return slices.Clone(cards)
+}
+
+// changes the turnPlayer to the next one if the turnPlayer isDone (if no more hand is to be played).
+// Finishes the round when there is no next player.
+func (t *Table) nextIfDone() error {
 	if t.turnPlayer.isDone() {
 		next := t.nextPlayer()
 		if next == nil {
-			t.turnPlayer = nil
-			t.gameState = done
-			return
+			return t.finish()
 		}
 		t.turnPlayer = next
+		return t.playBots()
+	}
+
+	t.dealSecondCard()
+	return nil
+}
+
+// finish ends the round. The dealer plays its hand if any hand can still beat it and every hand with a bet is settled.
+func (t *Table) finish() error {
+	t.turnPlayer = nil
+	t.gameState = done
+
+	if t.dealerMustPlay() {
+		for t.dealer.mustHit(t.rules.DealerHitsSoft17) {
+			t.dealer.hit(t.drawFaceDown())
+		}
+	}
+	// the face down cards are turned over together with the ones the dealer drew
+	t.reveal()
+
+	err := errors.Join(t.settle(), t.settleSideBets(AfterDealer))
+	t.round++
+
+	if len(t.deck) < t.cutCard {
+		t.shuffle()
+	}
+
+	return err
+}
+
+// shuffle replaces the deck with a fresh shoe. The next shuffle happens when only a quarter of it is left.
+func (t *Table) shuffle() {
+	t.deck = t.rules.shoe(t.rand)
+	t.cutCard = len(t.deck) / 4
+	for _, o := range t.observers {
+		o.Shuffled(len(t.deck))
+	}
+}
+
+// dealerMustPlay reports whether any player has a hand which is neither busted, surrendered nor a black jack
+// or a side bet which is settled after the dealer.
+func (t *Table) dealerMustPlay() bool {
+	for _, p := range t.players {
+		if p == nil {
+			continue
+		}
+		for _, placed := range p.hands.sideBets {
+			if timing(placed.bet) == AfterDealer {
+				return true
+			}
+		}
+		for _, h := range p.hands.all() {
+			score := Evaluate(h.cards)
+			if !h.surrendered && !score.Busted && (!score.BlackJack || !p.hands.natural()) && !t.rules.isCharlie(h) {
+				return true
+			}
+		}
 	}
+	return false
+}
+
+// settleSideBets pays every winning side bet with the given timing according to its paytable.
+func (t *Table) settleSideBets(when Timing) error {
+	var errs []error
+
+	for _, p := range t.players {
+		if p == nil {
+			continue
+		}
+		cards := SideBetCards{
+			Player:       p.hands.dealt(),
+			DealerUpCard: t.dealer.hand.cards[0],
+		}
+		if when == AfterDealer {
+			cards.Dealer = t.dealer.hand.cards
+		}
+		for _, placed := range p.hands.sideBets {
+			if timing(placed.bet) != when {
+				continue
+			}
+
+			combination, ok := placed.bet.Evaluate(cards)
+			if !ok {
+				continue
+			}
+
+			payout := placed.bet.Paytable()[combination]
+			err := p.credit(0, ReasonSideBetWin, placed.amount+payout.Of(placed.amount, t.rules.Rounding))
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// fiveCardTrick is what a five-card trick pays.
+var fiveCardTrick = Payout{Win: 2, Stake: 1}
+
+// half is what a surrendered hand gets back from its bet.
+var half = Payout{Win: 1, Stake: 2}
+
+// settle pays every hand with a bet according to its Outcome against the dealer.
+func (t *Table) settle() error {
+	dealer := Evaluate(t.dealer.hand.cards)
+	var errs []error
+
+	for _, p := range t.players {
+		if p == nil {
+			continue
+		}
+		for id, h := range p.hands.all() {
+			if h.stake() == 0 {
+				continue
+			}
+
+			var reason Reason
+			var amount Money
+			switch h.outcome(dealer, p.hands.natural(), t.rules) {
+			case BlackJackWin:
+				reason, amount = ReasonBlackJack, h.bet+t.rules.blackJackPayout().Of(h.bet, t.rules.Rounding)
+			case Win:
+				reason, amount = ReasonWin, 2*h.bet
+				if bonus, ok := twentyOneBonus(h.cards); ok && t.rules.TwentyOneBonuses && h.doubles == 0 {
+					reason, amount = ReasonBonus, h.bet+bonus.Of(h.bet, t.rules.Rounding)
+				}
+				// the free bet is paid even money, the house keeps its stake
+				amount += h.freeBet
+			case FiveCardTrickWin:
+				reason, amount = ReasonBonus, h.bet+fiveCardTrick.Of(h.bet, t.rules.Rounding)+h.freeBet
+			case Push:
+				reason, amount = ReasonPush, h.bet
+			case Surrendered:
+				reason, amount = ReasonSurrender, half.Of(h.bet, t.rules.Rounding)
+			}
+			if amount == 0 {
+				continue
+			}
+			if err := p.credit(id, reason, amount); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// deals the second card to the active hand of the turnPlayer after a split.
+func (t *Table) dealSecondCard() {
+	if len(t.turnPlayer.hands.active.cards) == 1 {
+		t.turnPlayer.Hit(t.drawCard())
+	}
+}
+
+// reports whether the player joined the table.
+func (t *Table) isSeated(p *Player) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, seated := range t.players {
+		if seated != nil && seated == p {
+			return true
+		}
+	}
+	return false
 }
 
 // determines the next player by looping through the players slice starting at the turnPlayer's index + 1.
@@ -207,25 +765,98 @@ func (t *Table) nextPlayer() *Player {
 	return nil
 }
 
-// draw a card from the deck off the Table and update the deck.
+// draw a card from the deck off the Table face up and update the deck.
 func (t *Table) drawCard() deck.Card {
+	card := t.drawFaceDown()
+	t.show(card)
+	return card
+}
+
+// draw a card from the deck off the Table without showing it to the observers and update the deck.
+// A round which needs more cards than are left in the shoe continues with a fresh one, see reshuffle.
+func (t *Table) drawFaceDown() deck.Card {
+	if len(t.deck) == 0 {
+		t.deck = t.rules.shoe(t.rand)
+		t.cutCard = len(t.deck) / 4
+		t.reshuffled = &reshuffle{cards: len(t.deck), dealer: len(t.dealer.hand.cards)}
+	}
 	cards, remaining := deck.Draw(1)(t.deck)
 	t.deck = remaining
 	return cards[0]
 }
 
 // New creates a pointer to Table with the default configuration like 6 shuffled decks and a maximum of 7 players allowed.
+// The shoe is shuffled again once only a quarter of it is left after a round, or when it runs out during a round.
 // Dealer must stand on soft 17.
 // No peek.
-// Double Down only allowed on 9 to 11.
-func New() *Table {
-	return &Table{
-		dealer:  newDealer(),
-		players: [7]*Player{},
-		deck: deck.New(
-			deck.WithDecks(6),
-			deck.Shuffle,
-		),
+// Double Down only allowed on 9 to 11, also after a split.
+// Black jack pays 3 to 2, payouts are rounded down to a whole minor unit.
+// No surrender.
+// No table limits.
+// The configuration can be changed by passing options.
+func New(opts ...func(t *Table) *Table) *Table {
+	t := &Table{
+		rules:      DefaultRules(),
+		dealer:     newDealer(),
+		players:    [7]*Player{},
+		ledger:     &Ledger{},
 		turnPlayer: nil,
 	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	t.shuffle()
+	return t
+}
+
+// WithRules is an option for New to play with the given rules instead of DefaultRules.
+func WithRules(rules Rules) func(t *Table) *Table {
+	return func(t *Table) *Table {
+		t.rules = rules
+		return t
+	}
+}
+
+// WithSideBets is an option for New to offer the given side bets at the table.
+func WithSideBets(bets ...SideBet) func(t *Table) *Table {
+	return func(t *Table) *Table {
+		t.sideBets = append(t.sideBets, bets...)
+		return t
+	}
+}
+
+// WithRand is an option for New to shuffle the shoe with the given source of randomness instead of the global one,
+// for example to replay a session with the same seed.
+func WithRand(r *rand.Rand) func(t *Table) *Table {
+	return func(t *Table) *Table {
+		t.rand = r
+		return t
+	}
+}
+
+// WithLedger is an option for New to record the transactions of the players in the given ledger.
+// A nil ledger records nothing, which keeps long simulations from growing the ledger without bounds.
+func WithLedger(ledger *Ledger) func(t *Table) *Table {
+	return func(t *Table) *Table {
+		t.ledger = ledger
+		return t
+	}
+}
+
+// WithTrainer is an option for New to enable the trainer mode. Every decision of the players is compared to
+// the advice of the coach and can be reviewed using TrainerReport.
+func WithTrainer(coach Coach) func(t *Table) *Table {
+	return func(t *Table) *Table {
+		t.trainer = &trainer{coach: coach}
+		return t
+	}
+}
+
+// WithBetLimits is an option for New to set the minimum and maximum bet of the table.
+func WithBetLimits(minBet, maxBet Money) func(t *Table) *Table {
+	return func(t *Table) *Table {
+		t.rules.MinBet = minBet
+		t.rules.MaxBet = maxBet
+		return t
+	}
 }
@@ -3,6 +3,7 @@ package blackjack
 import (
 	"errors"
 	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/Hydoc/deck"
@@ -25,6 +26,36 @@ func Test_New(t *testing.T) {
 	if !reflect.DeepEqual(wantPlayers, table.players) {
 		t.Errorf("want players %v, got %v", wantPlayers, table.players)
 	}
+
+	if !reflect.DeepEqual(table.rules, DefaultRules()) {
+		t.Errorf("want default rules, got %#v", table.rules)
+	}
+}
+
+func Test_NewWithOptions(t *testing.T) {
+	t.Run("with bet limits", func(t *testing.T) {
+		table := New(WithBetLimits(5, 500))
+		want := DefaultRules()
+		want.MinBet = 5
+		want.MaxBet = 500
+
+		if !reflect.DeepEqual(table.rules, want) {
+			t.Errorf("want %#v, got %#v", want, table.rules)
+		}
+
+		if got := table.State().Rules; !reflect.DeepEqual(got, want) {
+			t.Errorf("want state rules %#v, got %#v", want, got)
+		}
+	})
+
+	t.Run("with rules", func(t *testing.T) {
+		want := Rules{MinBet: 500, MaxBet: 10_000}
+		table := New(WithRules(want))
+
+		if !reflect.DeepEqual(table.rules, want) {
+			t.Errorf("want %#v, got %#v", want, table.rules)
+		}
+	})
 }
 
 func TestTable_Join(t *testing.T) {
@@ -147,6 +178,88 @@ func TestTable_Leave(t *testing.T) {
 	}
 }
 
+func TestTable_ShoeRunsOut(t *testing.T) {
+	t.Run("shuffle when the shoe runs out while dealing", func(t *testing.T) {
+		r := &recorder{}
+		table := New(WithObserver(r))
+		table.deck = stack(deck.Card{Rank: deck.Ten, Suit: deck.Heart})
+		player := NewPlayer(100 * Unit)
+		table.Join(player)
+		table.Bet(player, 10*Unit)
+
+		if err := table.Start(); err != nil {
+			t.Fatalf("want nil, got %v", err)
+		}
+		for table.InProgress() {
+			table.Stand()
+		}
+
+		// one shuffle when the table was created and one when the shoe ran out
+		if len(r.shuffled) != 2 {
+			t.Errorf("want %d shuffles, got %d", 2, len(r.shuffled))
+		}
+	})
+
+	t.Run("seven players hitting a single deck", func(t *testing.T) {
+		table := New(WithRules(Rules{Decks: 1}))
+		players := make([]*Player, 7)
+		for i := range players {
+			players[i] = NewPlayer(1000 * Unit)
+			table.Join(players[i])
+		}
+
+		for range 20 {
+			for _, p := range players {
+				table.Bet(p, Unit)
+			}
+			table.Start()
+			for table.InProgress() {
+				if err := table.Hit(); err != nil {
+					table.Stand()
+				}
+			}
+		}
+
+		if got := table.State().Round; got != 20 {
+			t.Errorf("want %d rounds, got %d", 20, got)
+		}
+	})
+}
+
+func TestTable_Leave_Refund(t *testing.T) {
+	t.Run("refund bets before the deal", func(t *testing.T) {
+		table := New(WithSideBets(NewPerfectPairs(nil)))
+		player := NewPlayer(100*Unit, WithName("One"))
+		table.Join(player)
+		table.Bet(player, 10*Unit)
+		table.PlaceSideBet(player, "Perfect Pairs", 5*Unit)
+
+		if err := table.Leave(player); err != nil {
+			t.Fatalf("want nil, got %v", err)
+		}
+
+		if got := player.Balance(); got != 100*Unit {
+			t.Errorf("want %s, got %s", 100*Unit, got)
+		}
+		if got := table.Ledger().Net(player.ID()); got != 0 {
+			t.Errorf("want a net of %s, got %s", Money(0), got)
+		}
+	})
+
+	t.Run("keep bets which were dealt", func(t *testing.T) {
+		table := New()
+		player := NewPlayer(100 * Unit)
+		table.Join(player)
+		table.Bet(player, 10*Unit)
+		table.Start()
+		table.Leave(player)
+
+		if got := player.Balance(); got == 100*Unit {
+			t.Errorf("want the dealt bet to be kept, got %s", got)
+		}
+	})
+}
+
 func TestTable_Start(t *testing.T) {
 	t.Run("join two players and start", func(t *testing.T) {
 		playerOne := NewPlayer(0, WithName("Player1"))
@@ -424,6 +537,7 @@ func TestTable_Hit(t *testing.T) {
 	t.Run("hit normally", func(t *testing.T) {
 		player := NewPlayer(200)
 		table := &Table{
+			dealer:     newDealer(),
 			turnPlayer: player,
 			deck:       deck.New(),
 		}
@@ -443,6 +557,7 @@ func TestTable_Hit(t *testing.T) {
 		player := NewPlayer(200)
 
 		table := &Table{
+			dealer:     newDealer(),
 			turnPlayer: player,
 			deck:       cards,
 		}
@@ -469,6 +584,7 @@ func TestTable_Hit(t *testing.T) {
 		playerTwo := NewPlayer(200)
 
 		table := &Table{
+			dealer:     newDealer(),
 			turnPlayer: playerOne,
 			players: [7]*Player{
 				playerOne,
@@ -571,3 +687,1167 @@ func TestTable_Stand(t *testing.T) {
 		}
 	})
 }
+
+func TestTable_Bet(t *testing.T) {
+	tests := []struct {
+		name       string
+		rules      Rules
+		wallet     Money
+		amount     Money
+		seated     bool
+		inProgress bool
+		wantErr    error
+		wantWallet Money
+		wantBet    Money
+	}{
+		{
+			name:       "bet within limits",
+			rules:      Rules{MinBet: 5, MaxBet: 500},
+			wallet:     1000,
+			amount:     100,
+			seated:     true,
+			wantWallet: 900,
+			wantBet:    100,
+		},
+		{
+			name:       "bet below minimum",
+			rules:      Rules{MinBet: 5, MaxBet: 500},
+			wallet:     1000,
+			amount:     4,
+			seated:     true,
+			wantErr:    ErrBetBelowMinimum,
+			wantWallet: 1000,
+		},
+		{
+			name:       "bet above maximum",
+			rules:      Rules{MinBet: 5, MaxBet: 500},
+			wallet:     1000,
+			amount:     501,
+			seated:     true,
+			wantErr:    ErrBetAboveMaximum,
+			wantWallet: 1000,
+		},
+		{
+			name:       "insufficient funds",
+			wallet:     50,
+			amount:     100,
+			seated:     true,
+			wantErr:    ErrInsufficientFunds,
+			wantWallet: 50,
+		},
+		{
+			name:       "huge bet with chips",
+			rules:      Rules{Chips: []Money{500, 2500}},
+			wallet:     1000,
+			amount:     100 * (1 << 55),
+			seated:     true,
+			wantErr:    ErrInsufficientFunds,
+			wantWallet: 1000,
+		},
+		{
+			name:       "nothing to bet",
+			wallet:     50,
+			amount:     0,
+			seated:     true,
+			wantErr:    ErrNotAllowed,
+			wantWallet: 50,
+		},
+		{
+			name:       "player not at the table",
+			wallet:     1000,
+			amount:     100,
+			wantErr:    ErrNotAtTable,
+			wantWallet: 1000,
+		},
+		{
+			name:       "round in progress",
+			wallet:     1000,
+			amount:     100,
+			seated:     true,
+			inProgress: true,
+			wantErr:    ErrRoundInProgress,
+			wantWallet: 1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := New(WithRules(tt.rules))
+			player := NewPlayer(tt.wallet)
+			if tt.seated {
+				table.Join(player)
+			}
+			if tt.inProgress {
+				table.turnPlayer = player
+			}
+
+			err := table.Bet(player, tt.amount)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("want %#v, got %#v", tt.wantErr, err)
+			}
+
+			if player.wallet.Balance() != tt.wantWallet {
+				t.Errorf("want wallet %d, got %d", tt.wantWallet, player.wallet.Balance())
+			}
+
+			if player.hands.active.bet != tt.wantBet {
+				t.Errorf("want bet %d, got %d", tt.wantBet, player.hands.active.bet)
+			}
+		})
+	}
+}
+
+func TestTable_DoubleDown(t *testing.T) {
+	t.Run("err when no turnPlayer", func(t *testing.T) {
+		table := &Table{}
+
+		if err := table.DoubleDown(); !errors.Is(err, ErrNoTurnPlayer) {
+			t.Errorf("want %#v, got %#v", ErrNoTurnPlayer, err)
+		}
+	})
+
+	t.Run("double down and end", func(t *testing.T) {
+		player := NewPlayer(1000)
+		player.hands = newHands(withBet(100))
+		player.hands.first.cards = []deck.Card{
+			{Rank: deck.Five, Suit: deck.Club},
+			{Rank: deck.Six, Suit: deck.Heart},
+		}
+		table := &Table{
+			dealer:     newDealer(),
+			rules:      Rules{MinBet: 5, MaxBet: 500},
+			turnPlayer: player,
+			players:    [7]*Player{player},
+			deck:       deck.New(),
+		}
+
+		if err := table.DoubleDown(); err != nil {
+			t.Errorf("want nil, got %v", err)
+		}
+
+		if len(player.hands.first.cards) != 3 {
+			t.Errorf("want 3 cards, got %d", len(player.hands.first.cards))
+		}
+
+		if player.hands.first.bet != 200 {
+			t.Errorf("want bet %d, got %d", 200, player.hands.first.bet)
+		}
+
+		if !table.IsDone() {
+			t.Errorf("table should be done")
+		}
+	})
+
+	t.Run("not double down above the table maximum", func(t *testing.T) {
+		player := NewPlayer(1000)
+		player.hands = newHands(withBet(400))
+		player.hands.first.cards = []deck.Card{
+			{Rank: deck.Five, Suit: deck.Club},
+			{Rank: deck.Six, Suit: deck.Heart},
+		}
+		table := &Table{
+			dealer:     newDealer(),
+			rules:      Rules{MinBet: 5, MaxBet: 300},
+			turnPlayer: player,
+			deck:       deck.New(),
+		}
+
+		if err := table.DoubleDown(); !errors.Is(err, ErrBetAboveMaximum) {
+			t.Errorf("want %#v, got %#v", ErrBetAboveMaximum, err)
+		}
+
+		if len(table.deck) != 52 {
+			t.Errorf("no card should have been drawn")
+		}
+
+		if player.wallet.Balance() != 1000 {
+			t.Errorf("want wallet %d, got %d", 1000, player.wallet.Balance())
+		}
+	})
+}
+
+func TestTable_Split(t *testing.T) {
+	newPair := func(bet Money) *Player {
+		player := NewPlayer(1000)
+		player.hands = newHands(withBet(bet))
+		player.hands.first.cards = []deck.Card{
+			{Rank: deck.Eight, Suit: deck.Club},
+			{Rank: deck.Eight, Suit: deck.Heart},
+		}
+		return player
+	}
+
+	t.Run("err when no turnPlayer", func(t *testing.T) {
+		table := &Table{}
+
+		if err := table.Split(); !errors.Is(err, ErrNoTurnPlayer) {
+			t.Errorf("want %#v, got %#v", ErrNoTurnPlayer, err)
+		}
+	})
+
+	t.Run("split and deal the second card to each hand in turn", func(t *testing.T) {
+		player := newPair(100)
+		table := &Table{
+			dealer:     newDealer(),
+			turnPlayer: player,
+			players:    [7]*Player{player},
+			deck:       deck.New(deck.Filter(func(c deck.Card) bool { return c.Rank == deck.Two })),
+		}
+
+		if err := table.Split(); err != nil {
+			t.Errorf("want nil, got %v", err)
+		}
+
+		if player.wallet.Balance() != 900 {
+			t.Errorf("want wallet %d, got %d", 900, player.wallet.Balance())
+		}
+
+		if len(player.hands.first.cards) != 2 || len(player.hands.second.cards) != 1 {
+			t.Errorf("only the first hand should have its second card")
+		}
+
+		if err := table.Stand(); err != nil {
+			t.Errorf("want nil, got %v", err)
+		}
+
+		if len(player.hands.second.cards) != 2 {
+			t.Errorf("the second hand should have its second card")
+		}
+
+		if player.hands.first.bet != 100 || player.hands.second.bet != 100 {
+			t.Errorf("both hands should have the original bet")
+		}
+
+		if table.turnPlayer != player {
+			t.Errorf("player should still be the turnPlayer")
+		}
+	})
+
+	t.Run("not split below the table minimum", func(t *testing.T) {
+		player := newPair(4)
+		table := &Table{
+			dealer:     newDealer(),
+			rules:      Rules{MinBet: 5},
+			turnPlayer: player,
+			deck:       deck.New(),
+		}
+
+		if err := table.Split(); !errors.Is(err, ErrBetBelowMinimum) {
+			t.Errorf("want %#v, got %#v", ErrBetBelowMinimum, err)
+		}
+
+		if player.hands.mode != normal {
+			t.Errorf("player should not have split")
+		}
+	})
+
+	t.Run("not split without a pair", func(t *testing.T) {
+		player := newPair(100)
+		player.hands.first.cards[1].Rank = deck.Nine
+		table := &Table{
+			dealer:     newDealer(),
+			turnPlayer: player,
+			deck:       deck.New(),
+		}
+
+		if err := table.Split(); !errors.Is(err, ErrNotAllowed) {
+			t.Errorf("want %#v, got %#v", ErrNotAllowed, err)
+		}
+	})
+}
+
+func TestTable_DoubleDownFor(t *testing.T) {
+	tests := []struct {
+		name        string
+		rules       Rules
+		amount      Money
+		wantErr     error
+		wantDoubled Money
+	}{
+		{
+			name:        "double for less when allowed",
+			rules:       Rules{DoubleForLess: true},
+			amount:      50,
+			wantDoubled: 50,
+		},
+		{
+			name:    "not double for less when not allowed",
+			rules:   Rules{},
+			amount:  50,
+			wantErr: ErrNotAllowed,
+		},
+		{
+			name:        "double for the full bet when doubling for less is not allowed",
+			rules:       Rules{},
+			amount:      100,
+			wantDoubled: 100,
+		},
+		{
+			name:    "not double for less below the table minimum",
+			rules:   Rules{MinBet: 25, DoubleForLess: true},
+			amount:  10,
+			wantErr: ErrBetBelowMinimum,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player := NewPlayer(1000)
+			player.hands = newHands(withBet(100))
+			player.hands.first.cards = []deck.Card{
+				{Rank: deck.Five, Suit: deck.Club},
+				{Rank: deck.Six, Suit: deck.Heart},
+			}
+			table := &Table{
+				dealer:     newDealer(),
+				rules:      tt.rules,
+				turnPlayer: player,
+				players:    [7]*Player{player},
+				deck:       deck.New(),
+			}
+
+			err := table.DoubleDownFor(tt.amount)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("want %#v, got %#v", tt.wantErr, err)
+			}
+
+			if player.hands.first.doubled != tt.wantDoubled {
+				t.Errorf("want doubled %d, got %d", tt.wantDoubled, player.hands.first.doubled)
+			}
+
+			if tt.wantErr == nil && !table.IsDone() {
+				t.Errorf("table should be done")
+			}
+		})
+	}
+}
+
+func TestTable_DoubleDown_ShortWallet(t *testing.T) {
+	tests := []struct {
+		name        string
+		rules       Rules
+		wantLegal   bool
+		wantErr     error
+		wantDoubled Money
+	}{
+		{name: "double for the balance", rules: Rules{DoubleForLess: true}, wantLegal: true, wantDoubled: 40},
+		{name: "not double without doubling for less", rules: Rules{}, wantErr: ErrNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player := NewPlayer(40)
+			player.hands = newHands(withBet(100))
+			player.hands.first.cards = []deck.Card{
+				{Rank: deck.Five, Suit: deck.Club},
+				{Rank: deck.Six, Suit: deck.Heart},
+			}
+			table := &Table{
+				dealer:     newDealer(),
+				rules:      tt.rules,
+				turnPlayer: player,
+				players:    [7]*Player{player},
+				deck:       deck.New(),
+			}
+
+			if got := slices.Contains(table.LegalActions(), ActionDoubleDown); got != tt.wantLegal {
+				t.Errorf("want double down legal %v, got %v", tt.wantLegal, got)
+			}
+
+			if err := table.DoubleDown(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("want %#v, got %#v", tt.wantErr, err)
+			}
+
+			if player.hands.first.doubled != tt.wantDoubled {
+				t.Errorf("want doubled %d, got %d", tt.wantDoubled, player.hands.first.doubled)
+			}
+		})
+	}
+}
+
+// stack returns the cards in the order they are drawn from the table's deck.
+func stack(cards ...deck.Card) []deck.Card {
+	out := make([]deck.Card, len(cards))
+	for i, c := range cards {
+		out[len(cards)-1-i] = c
+	}
+	return out
+}
+
+func TestTable_Settle(t *testing.T) {
+	tests := []struct {
+		name        string
+		deck        []deck.Card
+		play        func(table *Table)
+		wantBalance Money
+		wantEntries []Entry
+	}{
+		{
+			name: "win against the dealer",
+			deck: stack(
+				deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+				deck.Card{Rank: deck.Ten, Suit: deck.Club},
+				deck.Card{Rank: deck.Nine, Suit: deck.Heart},
+				deck.Card{Rank: deck.Seven, Suit: deck.Club},
+			),
+			play: func(table *Table) {
+				table.Stand()
+			},
+			wantBalance: 1100,
+			wantEntries: []Entry{
+				{Transaction: Transaction{Reason: ReasonBet, Amount: 100}, Kind: Debit, Player: "One", Balance: 900},
+				{Transaction: Transaction{Reason: ReasonWin, Amount: 200}, Kind: Credit, Player: "One", Balance: 1100},
+			},
+		},
+		{
+			name: "push against the dealer",
+			deck: stack(
+				deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+				deck.Card{Rank: deck.Ten, Suit: deck.Club},
+				deck.Card{Rank: deck.Seven, Suit: deck.Heart},
+				deck.Card{Rank: deck.Seven, Suit: deck.Club},
+			),
+			play: func(table *Table) {
+				table.Stand()
+			},
+			wantBalance: 1000,
+			wantEntries: []Entry{
+				{Transaction: Transaction{Reason: ReasonBet, Amount: 100}, Kind: Debit, Player: "One", Balance: 900},
+				{Transaction: Transaction{Reason: ReasonPush, Amount: 100}, Kind: Credit, Player: "One", Balance: 1000},
+			},
+		},
+		{
+			name: "lose after busting",
+			deck: stack(
+				deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+				deck.Card{Rank: deck.Ten, Suit: deck.Club},
+				deck.Card{Rank: deck.Six, Suit: deck.Heart},
+				deck.Card{Rank: deck.Seven, Suit: deck.Club},
+				deck.Card{Rank: deck.Queen, Suit: deck.Heart},
+			),
+			play: func(table *Table) {
+				table.Hit()
+			},
+			wantBalance: 900,
+			wantEntries: []Entry{
+				{Transaction: Transaction{Reason: ReasonBet, Amount: 100}, Kind: Debit, Player: "One", Balance: 900},
+			},
+		},
+		{
+			name: "black jack pays 3 to 2 right after dealing",
+			deck: stack(
+				deck.Card{Rank: deck.Ace, Suit: deck.Heart},
+				deck.Card{Rank: deck.Ten, Suit: deck.Club},
+				deck.Card{Rank: deck.King, Suit: deck.Heart},
+				deck.Card{Rank: deck.Seven, Suit: deck.Club},
+			),
+			play:        func(table *Table) {},
+			wantBalance: 1150,
+			wantEntries: []Entry{
+				{Transaction: Transaction{Reason: ReasonBet, Amount: 100}, Kind: Debit, Player: "One", Balance: 900},
+				{Transaction: Transaction{Reason: ReasonBlackJack, Amount: 250}, Kind: Credit, Player: "One", Balance: 1150},
+			},
+		},
+		{
+			name: "dealer draws and busts",
+			deck: stack(
+				deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+				deck.Card{Rank: deck.Ten, Suit: deck.Club},
+				deck.Card{Rank: deck.Two, Suit: deck.Heart},
+				deck.Card{Rank: deck.Five, Suit: deck.Club},
+				deck.Card{Rank: deck.Queen, Suit: deck.Spade},
+			),
+			play: func(table *Table) {
+				table.Stand()
+			},
+			wantBalance: 1100,
+			wantEntries: []Entry{
+				{Transaction: Transaction{Reason: ReasonBet, Amount: 100}, Kind: Debit, Player: "One", Balance: 900},
+				{Transaction: Transaction{Reason: ReasonWin, Amount: 200}, Kind: Credit, Player: "One", Balance: 1100},
+			},
+		},
+		{
+			name: "split hands are settled on their own",
+			deck: stack(
+				deck.Card{Rank: deck.Eight, Suit: deck.Heart},
+				deck.Card{Rank: deck.Ten, Suit: deck.Club},
+				deck.Card{Rank: deck.Eight, Suit: deck.Spade},
+				deck.Card{Rank: deck.Eight, Suit: deck.Club},
+				deck.Card{Rank: deck.Ace, Suit: deck.Heart},
+				deck.Card{Rank: deck.Nine, Suit: deck.Heart},
+			),
+			play: func(table *Table) {
+				table.Split()
+				table.Stand()
+				table.Stand()
+			},
+			wantBalance: 1000,
+			wantEntries: []Entry{
+				{Transaction: Transaction{Reason: ReasonBet, Amount: 100}, Kind: Debit, Player: "One", Balance: 900},
+				{Transaction: Transaction{Hand: 1, Reason: ReasonSplit, Amount: 100}, Kind: Debit, Player: "One", Balance: 800},
+				{Transaction: Transaction{Reason: ReasonWin, Amount: 200}, Kind: Credit, Player: "One", Balance: 1000},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := New()
+			table.deck = tt.deck
+			player := NewPlayer(1000, WithName("One"))
+			table.Join(player)
+
+			if err := table.Bet(player, 100); err != nil {
+				t.Fatalf("want nil, got %v", err)
+			}
+
+			if err := table.Start(); err != nil {
+				t.Fatalf("want nil, got %v", err)
+			}
+
+			tt.play(table)
+
+			if !table.IsDone() {
+				t.Errorf("table should be done")
+			}
+
+			if got := player.Balance(); got != tt.wantBalance {
+				t.Errorf("want balance %d, got %d", tt.wantBalance, got)
+			}
+
+			for i := range tt.wantEntries {
+				tt.wantEntries[i].PlayerID = player.ID()
+			}
+			if got := table.Ledger().Entries(); !reflect.DeepEqual(got, tt.wantEntries) {
+				t.Errorf("want %#v, got %#v", tt.wantEntries, got)
+			}
+
+			if got := table.State().Round; got != 1 {
+				t.Errorf("want round %d, got %d", 1, got)
+			}
+		})
+	}
+}
+
+func TestTable_StartNextRound(t *testing.T) {
+	table := New()
+	table.deck = stack(
+		deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+		deck.Card{Rank: deck.Ten, Suit: deck.Club},
+		deck.Card{Rank: deck.Nine, Suit: deck.Heart},
+		deck.Card{Rank: deck.Seven, Suit: deck.Club},
+		deck.Card{Rank: deck.Two, Suit: deck.Heart},
+		deck.Card{Rank: deck.Three, Suit: deck.Club},
+		deck.Card{Rank: deck.Four, Suit: deck.Heart},
+		deck.Card{Rank: deck.Five, Suit: deck.Club},
+	)
+	table.cutCard = 0
+	player := NewPlayer(1000, WithName("One"))
+	table.Join(player)
+
+	table.Bet(player, 100)
+	table.Start()
+
+	if err := table.Start(); !errors.Is(err, ErrRoundInProgress) {
+		t.Errorf("want %#v, got %#v", ErrRoundInProgress, err)
+	}
+
+	table.Stand()
+
+	if err := table.Bet(player, 50); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	if err := table.Start(); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	wantPlayerCards := []deck.Card{
+		{Rank: deck.Two, Suit: deck.Heart},
+		{Rank: deck.Four, Suit: deck.Heart},
+	}
+	if !reflect.DeepEqual(player.hands.first.cards, wantPlayerCards) {
+		t.Errorf("want %#v, got %#v", wantPlayerCards, player.hands.first.cards)
+	}
+
+	if len(table.dealer.hand.cards) != 2 {
+		t.Errorf("dealer should have a new hand")
+	}
+
+	if player.hands.first.bet != 50 {
+		t.Errorf("want bet %d, got %d", 50, player.hands.first.bet)
+	}
+
+	if got := table.Ledger().Round(1); len(got) != 1 || got[0].Amount != 50 {
+		t.Errorf("want the bet of the second round, got %#v", got)
+	}
+}
+
+func TestTable_BlackJackPayout(t *testing.T) {
+	tests := []struct {
+		name        string
+		rules       Rules
+		bet         Money
+		wantBalance Money
+	}{
+		{
+			name:        "5.00 black jack pays 7.50",
+			bet:         5 * Unit,
+			wantBalance: 100*Unit + 750,
+		},
+		{
+			name:        "odd bet rounds down",
+			bet:         501,
+			wantBalance: 100*Unit + 751,
+		},
+		{
+			name:        "odd bet rounds half up",
+			rules:       Rules{Rounding: RoundHalfUp},
+			bet:         501,
+			wantBalance: 100*Unit + 752,
+		},
+		{
+			name:        "6 to 5",
+			rules:       Rules{BlackJackPayout: SixToFive},
+			bet:         10 * Unit,
+			wantBalance: 100*Unit + 12*Unit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := New(WithRules(tt.rules))
+			table.deck = stack(
+				deck.Card{Rank: deck.Ace, Suit: deck.Heart},
+				deck.Card{Rank: deck.Ten, Suit: deck.Club},
+				deck.Card{Rank: deck.King, Suit: deck.Heart},
+				deck.Card{Rank: deck.Seven, Suit: deck.Club},
+			)
+			player := NewPlayer(100 * Unit)
+			table.Join(player)
+			table.Bet(player, tt.bet)
+
+			if err := table.Start(); err != nil {
+				t.Fatalf("want nil, got %v", err)
+			}
+
+			if got := player.Balance(); got != tt.wantBalance {
+				t.Errorf("want %s, got %s", tt.wantBalance, got)
+			}
+		})
+	}
+}
+
+func TestTable_Charlie(t *testing.T) {
+	tests := []struct {
+		name        string
+		charlie     int
+		hits        int
+		wantDone    bool
+		wantBalance Money
+	}{
+		{
+			name:        "five-card charlie wins against dealer black jack",
+			charlie:     5,
+			hits:        3,
+			wantDone:    true,
+			wantBalance: 110 * Unit,
+		},
+		{
+			name:        "six-card charlie keeps playing after five cards",
+			charlie:     6,
+			hits:        3,
+			wantDone:    false,
+			wantBalance: 90 * Unit,
+		},
+		{
+			name:        "six-card charlie",
+			charlie:     6,
+			hits:        4,
+			wantDone:    true,
+			wantBalance: 110 * Unit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := New(WithRules(Rules{Charlie: tt.charlie}))
+			table.deck = stack(
+				deck.Card{Rank: deck.Two, Suit: deck.Heart},
+				deck.Card{Rank: deck.Ace, Suit: deck.Club},
+				deck.Card{Rank: deck.Two, Suit: deck.Club},
+				deck.Card{Rank: deck.King, Suit: deck.Club},
+				deck.Card{Rank: deck.Two, Suit: deck.Spade},
+				deck.Card{Rank: deck.Three, Suit: deck.Spade},
+				deck.Card{Rank: deck.Three, Suit: deck.Heart},
+				deck.Card{Rank: deck.Four, Suit: deck.Heart},
+			)
+			player := NewPlayer(100 * Unit)
+			table.Join(player)
+			table.Bet(player, 10*Unit)
+
+			if err := table.Start(); err != nil {
+				t.Fatalf("want nil, got %v", err)
+			}
+
+			for range tt.hits {
+				if err := table.Hit(); err != nil {
+					t.Fatalf("want nil, got %v", err)
+				}
+			}
+
+			if got := table.IsDone(); got != tt.wantDone {
+				t.Errorf("want %v, got %v", tt.wantDone, got)
+			}
+
+			if got := player.Balance(); got != tt.wantBalance {
+				t.Errorf("want %s, got %s", tt.wantBalance, got)
+			}
+		})
+	}
+}
+
+func TestTable_Peek(t *testing.T) {
+	tests := []struct {
+		name        string
+		rules       Rules
+		wantDone    bool
+		wantBalance Money
+	}{
+		{
+			name:        "dealer black jack ends the round",
+			rules:       Rules{Peek: true},
+			wantDone:    true,
+			wantBalance: 90 * Unit,
+		},
+		{
+			name:        "without peek the player plays",
+			wantDone:    false,
+			wantBalance: 90 * Unit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := New(WithRules(tt.rules))
+			table.deck = stack(
+				deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+				deck.Card{Rank: deck.Ace, Suit: deck.Club},
+				deck.Card{Rank: deck.Seven, Suit: deck.Heart},
+				deck.Card{Rank: deck.King, Suit: deck.Club},
+			)
+			player := NewPlayer(100 * Unit)
+			table.Join(player)
+			table.Bet(player, 10*Unit)
+
+			if err := table.Start(); err != nil {
+				t.Fatalf("want nil, got %v", err)
+			}
+
+			if got := table.IsDone(); got != tt.wantDone {
+				t.Errorf("want %v, got %v", tt.wantDone, got)
+			}
+
+			if got := player.Balance(); got != tt.wantBalance {
+				t.Errorf("want %s, got %s", tt.wantBalance, got)
+			}
+		})
+	}
+}
+
+func TestTable_LegalActions(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules Rules
+		cards []deck.Card
+		want  []Action
+	}{
+		{
+			name:  "hard 10",
+			rules: DefaultRules(),
+			cards: stack(
+				deck.Card{Rank: deck.Six, Suit: deck.Heart},
+				deck.Card{Rank: deck.Ten, Suit: deck.Club},
+				deck.Card{Rank: deck.Four, Suit: deck.Heart},
+				deck.Card{Rank: deck.Seven, Suit: deck.Club},
+			),
+			want: []Action{ActionHit, ActionStand, ActionDoubleDown},
+		},
+		{
+			name:  "pair with surrender",
+			rules: Rules{Surrender: true},
+			cards: stack(
+				deck.Card{Rank: deck.Eight, Suit: deck.Heart},
+				deck.Card{Rank: deck.Ten, Suit: deck.Club},
+				deck.Card{Rank: deck.Eight, Suit: deck.Spade},
+				deck.Card{Rank: deck.Seven, Suit: deck.Club},
+			),
+			want: []Action{ActionHit, ActionStand, ActionSplit, ActionSurrender},
+		},
+		{
+			name:  "below the stand minimum",
+			rules: Rules{MinStand: 15},
+			cards: stack(
+				deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+				deck.Card{Rank: deck.Ten, Suit: deck.Club},
+				deck.Card{Rank: deck.Four, Suit: deck.Spade},
+				deck.Card{Rank: deck.Seven, Suit: deck.Club},
+			),
+			want: []Action{ActionHit},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := New(WithRules(tt.rules))
+			table.deck = tt.cards
+			player := NewPlayer(100 * Unit)
+			table.Join(player)
+			table.Bet(player, 10*Unit)
+			table.Start()
+
+			if got := table.LegalActions(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if got := New().LegalActions(); got != nil {
+		t.Errorf("want nil without turn player, got %v", got)
+	}
+}
+
+func TestTable_Surrender(t *testing.T) {
+	tests := []struct {
+		name        string
+		rules       Rules
+		dealer      deck.Card
+		bet         Money
+		wantErr     error
+		wantBalance Money
+	}{
+		{
+			name:        "get half of the bet back",
+			rules:       Rules{Surrender: true},
+			dealer:      deck.Card{Rank: deck.Nine, Suit: deck.Club},
+			bet:         10 * Unit,
+			wantBalance: 95 * Unit,
+		},
+		{
+			name:        "odd bet rounds down",
+			rules:       Rules{Surrender: true},
+			dealer:      deck.Card{Rank: deck.Nine, Suit: deck.Club},
+			bet:         501,
+			wantBalance: 100*Unit - 251,
+		},
+		{
+			name:        "odd bet rounds half up",
+			rules:       Rules{Surrender: true, Rounding: RoundHalfUp},
+			dealer:      deck.Card{Rank: deck.Nine, Suit: deck.Club},
+			bet:         501,
+			wantBalance: 100*Unit - 250,
+		},
+		{
+			name:        "late surrender loses everything against a dealer black jack",
+			rules:       Rules{Surrender: true},
+			dealer:      deck.Card{Rank: deck.Ace, Suit: deck.Club},
+			bet:         10 * Unit,
+			wantBalance: 90 * Unit,
+		},
+		{
+			name:        "not allowed by the house",
+			dealer:      deck.Card{Rank: deck.Nine, Suit: deck.Club},
+			bet:         10 * Unit,
+			wantErr:     ErrNotAllowed,
+			wantBalance: 90 * Unit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := New(WithRules(tt.rules))
+			table.deck = stack(
+				deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+				deck.Card{Rank: deck.King, Suit: deck.Club},
+				deck.Card{Rank: deck.Six, Suit: deck.Heart},
+				tt.dealer,
+			)
+			player := NewPlayer(100 * Unit)
+			table.Join(player)
+			table.Bet(player, tt.bet)
+			table.Start()
+
+			err := table.Surrender()
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("want %#v, got %#v", tt.wantErr, err)
+			}
+
+			if tt.wantErr == nil && !table.IsDone() {
+				t.Errorf("table should be done")
+			}
+
+			if got := player.Balance(); got != tt.wantBalance {
+				t.Errorf("want %s, got %s", tt.wantBalance, got)
+			}
+		})
+	}
+}
+
+func TestTable_Bet_Replace(t *testing.T) {
+	table := New(WithSideBets(NewPerfectPairs(nil)))
+	player := NewPlayer(100*Unit, WithName("One"))
+	table.Join(player)
+
+	table.Bet(player, 10*Unit)
+	table.PlaceSideBet(player, "Perfect Pairs", 5*Unit)
+	if err := table.Bet(player, 20*Unit); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	if got := player.Balance(); got != 80*Unit {
+		t.Errorf("want %s, got %s", 80*Unit, got)
+	}
+	if got := table.Ledger().Net(player.ID()); got != -20*Unit {
+		t.Errorf("want a net of %s, got %s", -20*Unit, got)
+	}
+	if got := player.hands.first.bet; got != 20*Unit || len(player.hands.sideBets) != 0 {
+		t.Errorf("want only the second bet in play, got %s and %d side bets", got, len(player.hands.sideBets))
+	}
+}
+
+func TestTable_Bet_RejectedReplace(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   Rules
+		amount  Money
+		wantErr error
+	}{
+		{name: "nothing to bet", amount: 0, wantErr: ErrNotAllowed},
+		{name: "negative bet", amount: -Unit, wantErr: ErrNotAllowed},
+		{name: "above maximum", rules: Rules{MaxBet: 50 * Unit}, amount: 60 * Unit, wantErr: ErrBetAboveMaximum},
+		{name: "insufficient funds", amount: 200 * Unit, wantErr: ErrInsufficientFunds},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := New(WithRules(tt.rules))
+			player := NewPlayer(100 * Unit)
+			table.Join(player)
+			table.Bet(player, 10*Unit)
+
+			if err := table.Bet(player, tt.amount); !errors.Is(err, tt.wantErr) {
+				t.Errorf("want %#v, got %#v", tt.wantErr, err)
+			}
+
+			if got := player.Balance(); got != 90*Unit {
+				t.Errorf("want %s, got %s", 90*Unit, got)
+			}
+			if got := player.pending(); got != 10*Unit {
+				t.Errorf("want the earlier bet of %s pending, got %s", 10*Unit, got)
+			}
+		})
+	}
+}
+
+func TestTable_PlaceSideBet(t *testing.T) {
+	tests := []struct {
+		name        string
+		sideBet     string
+		mainBet     Money
+		amount      Money
+		wantErr     error
+		wantBalance Money
+	}{
+		{
+			name:        "place correctly",
+			sideBet:     "21+3",
+			mainBet:     10 * Unit,
+			amount:      5 * Unit,
+			wantBalance: 85 * Unit,
+		},
+		{
+			name:        "unknown side bet",
+			sideBet:     "Lucky Lucky",
+			mainBet:     10 * Unit,
+			amount:      5 * Unit,
+			wantErr:     ErrUnknownSideBet,
+			wantBalance: 90 * Unit,
+		},
+		{
+			name:        "without main bet",
+			sideBet:     "Perfect Pairs",
+			amount:      5 * Unit,
+			wantErr:     ErrNoMainBet,
+			wantBalance: 100 * Unit,
+		},
+		{
+			name:        "insufficient funds",
+			sideBet:     "Perfect Pairs",
+			mainBet:     90 * Unit,
+			amount:      20 * Unit,
+			wantErr:     ErrInsufficientFunds,
+			wantBalance: 10 * Unit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := New(WithSideBets(NewTwentyOnePlusThree(nil), NewPerfectPairs(nil)))
+			player := NewPlayer(100 * Unit)
+			table.Join(player)
+			if tt.mainBet > 0 {
+				table.Bet(player, tt.mainBet)
+			}
+
+			err := table.PlaceSideBet(player, tt.sideBet, tt.amount)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("want %#v, got %#v", tt.wantErr, err)
+			}
+
+			if got := player.Balance(); got != tt.wantBalance {
+				t.Errorf("want %s, got %s", tt.wantBalance, got)
+			}
+		})
+	}
+}
+
+func TestTable_SettleSideBets_PaytableWithoutStake(t *testing.T) {
+	table := New(WithSideBets(NewPerfectPairs(Paytable{PerfectPair: {Win: 25}})))
+	table.deck = stack(
+		deck.Card{Rank: deck.Seven, Suit: deck.Heart},
+		deck.Card{Rank: deck.Ten, Suit: deck.Club},
+		deck.Card{Rank: deck.Seven, Suit: deck.Heart},
+		deck.Card{Rank: deck.Nine, Suit: deck.Club},
+	)
+	player := NewPlayer(100 * Unit)
+	table.Join(player)
+	table.Bet(player, 10*Unit)
+	table.PlaceSideBet(player, "Perfect Pairs", 5*Unit)
+
+	if err := table.Start(); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	// the perfect pair pays 25:1
+	if want := 85*Unit + 5*Unit + 125*Unit; player.Balance() != want {
+		t.Errorf("want %s, got %s", want, player.Balance())
+	}
+}
+
+func TestTable_SettleSideBets(t *testing.T) {
+	table := New(WithSideBets(NewTwentyOnePlusThree(nil), NewPerfectPairs(nil)))
+	table.deck = stack(
+		deck.Card{Rank: deck.Seven, Suit: deck.Heart},
+		deck.Card{Rank: deck.Seven, Suit: deck.Spade},
+		deck.Card{Rank: deck.Seven, Suit: deck.Diamond},
+		deck.Card{Rank: deck.Ten, Suit: deck.Club},
+	)
+	player := NewPlayer(100*Unit, WithName("One"))
+	table.Join(player)
+	table.Bet(player, 10*Unit)
+	table.PlaceSideBet(player, "21+3", 5*Unit)
+	table.PlaceSideBet(player, "Perfect Pairs", 5*Unit)
+
+	if err := table.Start(); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	// three of a kind pays 30:1, the colored pair pays 12:1
+	want := 80*Unit + 5*Unit + 150*Unit + 5*Unit + 60*Unit
+	if got := player.Balance(); got != want {
+		t.Errorf("want %s, got %s", want, got)
+	}
+
+	wins := 0
+	for _, e := range table.Ledger().Entries() {
+		if e.Reason == ReasonSideBetWin {
+			wins++
+		}
+	}
+	if wins != 2 {
+		t.Errorf("want %d side bet wins in the ledger, got %d", 2, wins)
+	}
+
+	if !table.InProgress() {
+		t.Errorf("table should be in progress")
+	}
+}
+
+func TestTable_SettleDealerSideBets(t *testing.T) {
+	table := New(WithSideBets(NewBusterBlackjack(Paytable{BustWith(3): {Win: 2, Stake: 1}})))
+	table.deck = stack(
+		deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+		deck.Card{Rank: deck.Ten, Suit: deck.Spade},
+		deck.Card{Rank: deck.Six, Suit: deck.Diamond},
+		deck.Card{Rank: deck.Five, Suit: deck.Club},
+		deck.Card{Rank: deck.Nine, Suit: deck.Club},
+		deck.Card{Rank: deck.Queen, Suit: deck.Club},
+	)
+	player := NewPlayer(100*Unit, WithName("One"))
+	table.Join(player)
+	table.Bet(player, 10*Unit)
+	table.PlaceSideBet(player, "Buster Blackjack", 5*Unit)
+
+	if err := table.Start(); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	if got := player.Balance(); got != 85*Unit {
+		t.Errorf("side bet should not be settled before the dealer played, got %s", got)
+	}
+
+	// the player busts, the dealer still has to play for the side bet
+	table.Hit()
+
+	if len(table.dealer.hand.cards) != 3 {
+		t.Errorf("want the dealer to draw to 25, got %#v", table.dealer.hand.cards)
+	}
+
+	want := 85*Unit + 5*Unit + 10*Unit
+	if got := player.Balance(); got != want {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
+
+func TestTable_RoundAllocations(t *testing.T) {
+	table := New(WithLedger(nil))
+	player := NewPlayer(1_000_000 * Unit)
+	table.Join(player)
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		playRound(table, player)
+	})
+
+	// a round starts new hands and a new dealer hand, everything else must not allocate
+	if allocs > 7 {
+		t.Errorf("want at most %d allocations per round, got %.0f", 7, allocs)
+	}
+}
+
+func BenchmarkTable_Round(b *testing.B) {
+	table := New(WithLedger(nil))
+	player := NewPlayer(1_000_000_000 * Unit)
+	table.Join(player)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		playRound(table, player)
+	}
+}
+
+// playRound plays a round hitting below 17.
+func playRound(table *Table, player *Player) {
+	table.Bet(player, 10*Unit)
+	table.Start()
+	for table.InProgress() {
+		if Evaluate(player.Cards()).Total < 17 {
+			table.Hit()
+			continue
+		}
+		table.Stand()
+	}
+}
@@ -0,0 +1,136 @@
+package blackjack
+
+import (
+	"slices"
+
+	"github.com/Hydoc/deck"
+)
+
+// Coach advises on the decisions of players in trainer mode. The strategy package provides one for any Rules.
+type Coach interface {
+	// Advise returns the best of the legal actions for the cards against the dealer's up card
+	// and the expected value of every legal action.
+	Advise(cards []deck.Card, upCard deck.Card, legal []Action) (Action, map[Action]float64)
+}
+
+// Decision is an action a player took in trainer mode together with the advice of the coach.
+type Decision struct {
+	Round int
+	// PlayerID identifies the player, see Player.ID. Player is only the name, which several players may share.
+	PlayerID uint64
+	Player   string
+	Cards    []deck.Card
+	UpCard   deck.Card
+	Action   Action
+	Best     Action
+	// Cost is the expected value lost by taking Action instead of Best, in units of the bet.
+	Cost float64
+}
+
+// Correct returns a bool whether the player took the best action.
+func (d Decision) Correct() bool {
+	return d.Action == d.Best
+}
+
+// Report holds every decision of a trainer session.
+type Report struct {
+	Decisions []Decision
+}
+
+// Accuracy returns the share of correct decisions between 0 and 1. Without decisions it is 1.
+func (r Report) Accuracy() float64 {
+	if len(r.Decisions) == 0 {
+		return 1
+	}
+	return float64(len(r.Decisions)-len(r.Mistakes())) / float64(len(r.Decisions))
+}
+
+// Mistakes returns the decisions which were not correct.
+func (r Report) Mistakes() []Decision {
+	var mistakes []Decision
+	for _, d := range r.Decisions {
+		if !d.Correct() {
+			mistakes = append(mistakes, d)
+		}
+	}
+	return mistakes
+}
+
+// Cost returns the expected value lost by all mistakes, in units of the bet.
+func (r Report) Cost() float64 {
+	cost := 0.0
+	for _, d := range r.Decisions {
+		cost += d.Cost
+	}
+	return cost
+}
+
+// Player returns the report of the decisions of the player with the given ID, see Player.ID.
+func (r Report) Player(id uint64) Report {
+	var decisions []Decision
+	for _, d := range r.Decisions {
+		if d.PlayerID == id {
+			decisions = append(decisions, d)
+		}
+	}
+	return Report{Decisions: decisions}
+}
+
+type trainer struct {
+	coach     Coach
+	decisions []Decision
+}
+
+// TrainerReport returns the report of every decision made at the table since it was created.
+// It is empty unless the table was created WithTrainer.
+func (t *Table) TrainerReport() Report {
+	if t.trainer == nil {
+		return Report{}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	decisions := make([]Decision, len(t.trainer.decisions))
+	copy(decisions, t.trainer.decisions)
+	return Report{Decisions: decisions}
+}
+
+// review asks the coach about the action the turnPlayer is about to take and records the decision.
+// Actions which are not legal are not recorded, since they are not taken. Neither are actions taken without
+// an up card, e.g. at Pontoon, since the coach can not advise without one.
+func (t *Table) review(action Action) {
+	if t.trainer == nil {
+		return
+	}
+	upCard, ok := t.UpCard()
+	if !ok {
+		return
+	}
+
+	legal := t.LegalActions()
+	if !slices.Contains(legal, action) {
+		return
+	}
+
+	p := t.turnPlayer
+	cards := slices.Clone(p.hands.active.cards)
+	best, ev := t.trainer.coach.Advise(cards, upCard, legal)
+
+	decision := Decision{
+		Round:    t.round,
+		PlayerID: p.id,
+		Player:   p.Name,
+		Cards:    cards,
+		UpCard:   upCard,
+		Action:   action,
+		Best:     best,
+	}
+	if value, ok := ev[action]; ok && action != best {
+		decision.Cost = ev[best] - value
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.trainer.decisions = append(t.trainer.decisions, decision)
+}
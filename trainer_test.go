@@ -0,0 +1,167 @@
+package blackjack
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/Hydoc/deck"
+)
+
+// alwaysStand advises to stand and values every other action one bet less.
+type alwaysStand struct{}
+
+func (alwaysStand) Advise(_ []deck.Card, _ deck.Card, legal []Action) (Action, map[Action]float64) {
+	ev := make(map[Action]float64, len(legal))
+	for _, a := range legal {
+		ev[a] = -1
+	}
+	ev[ActionStand] = 0
+	return ActionStand, ev
+}
+
+func TestTable_Trainer(t *testing.T) {
+	table := New(WithTrainer(alwaysStand{}))
+	table.deck = stack(
+		deck.Card{Rank: deck.Two, Suit: deck.Heart},
+		deck.Card{Rank: deck.Ten, Suit: deck.Club},
+		deck.Card{Rank: deck.Three, Suit: deck.Heart},
+		deck.Card{Rank: deck.Seven, Suit: deck.Club},
+		deck.Card{Rank: deck.Four, Suit: deck.Spade},
+	)
+	player := NewPlayer(100*Unit, WithName("Alice"))
+	table.Join(player)
+	table.Bet(player, 10*Unit)
+	table.Start()
+
+	if err := table.Hit(); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+	if err := table.Stand(); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+	if err := table.Stand(); err == nil {
+		t.Fatalf("want error after the round, got nil")
+	}
+
+	report := table.TrainerReport()
+	want := []Decision{
+		{
+			Round:    0,
+			PlayerID: player.ID(),
+			Player:   "Alice",
+			Cards:    []deck.Card{{Rank: deck.Two, Suit: deck.Heart}, {Rank: deck.Three, Suit: deck.Heart}},
+			UpCard:   deck.Card{Rank: deck.Ten, Suit: deck.Club},
+			Action:   ActionHit,
+			Best:     ActionStand,
+			Cost:     1,
+		},
+		{
+			Round:    0,
+			PlayerID: player.ID(),
+			Player:   "Alice",
+			Cards: []deck.Card{
+				{Rank: deck.Two, Suit: deck.Heart},
+				{Rank: deck.Three, Suit: deck.Heart},
+				{Rank: deck.Four, Suit: deck.Spade},
+			},
+			UpCard: deck.Card{Rank: deck.Ten, Suit: deck.Club},
+			Action: ActionStand,
+			Best:   ActionStand,
+		},
+	}
+
+	if !reflect.DeepEqual(report.Decisions, want) {
+		t.Errorf("want %#v, got %#v", want, report.Decisions)
+	}
+
+	if got := report.Accuracy(); got != 0.5 {
+		t.Errorf("want %f, got %f", 0.5, got)
+	}
+
+	if got := report.Cost(); got != 1 {
+		t.Errorf("want %f, got %f", 1.0, got)
+	}
+}
+
+func TestTable_TrainerIllegalAction(t *testing.T) {
+	table := New(WithTrainer(alwaysStand{}))
+	table.deck = stack(
+		deck.Card{Rank: deck.Ten, Suit: deck.Heart},
+		deck.Card{Rank: deck.Ten, Suit: deck.Club},
+		deck.Card{Rank: deck.Seven, Suit: deck.Heart},
+		deck.Card{Rank: deck.Seven, Suit: deck.Club},
+	)
+	player := NewPlayer(100 * Unit)
+	table.Join(player)
+	table.Bet(player, 10*Unit)
+	table.Start()
+
+	table.DoubleDown()
+	table.Split()
+
+	if got := len(table.TrainerReport().Decisions); got != 0 {
+		t.Errorf("want no decisions, got %d", got)
+	}
+}
+
+func TestTable_TrainerDealerHidden(t *testing.T) {
+	table := New(WithRules(Rules{DealerHidden: true}), WithTrainer(alwaysStand{}))
+	table.deck = stack(
+		deck.Card{Rank: deck.Two, Suit: deck.Heart},
+		deck.Card{Rank: deck.Ten, Suit: deck.Club},
+		deck.Card{Rank: deck.Three, Suit: deck.Heart},
+		deck.Card{Rank: deck.Seven, Suit: deck.Club},
+		deck.Card{Rank: deck.Four, Suit: deck.Spade},
+	)
+	player := NewPlayer(100 * Unit)
+	table.Join(player)
+	table.Bet(player, 10*Unit)
+	table.Start()
+
+	if err := table.Hit(); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+
+	// the face down cards of the dealer are not given to the coach
+	if got := len(table.TrainerReport().Decisions); got != 0 {
+		t.Errorf("want no decisions, got %d", got)
+	}
+}
+
+func TestReport(t *testing.T) {
+	report := Report{Decisions: []Decision{
+		{PlayerID: 1, Player: "Alice", Action: ActionHit, Best: ActionHit},
+		{PlayerID: 1, Player: "Alice", Action: ActionStand, Best: ActionHit, Cost: 0.25},
+		{PlayerID: 2, Player: "Alice", Action: ActionSplit, Best: ActionStand, Cost: 0.5},
+	}}
+
+	tests := []struct {
+		name         string
+		report       Report
+		wantAccuracy float64
+		wantMistakes int
+		wantCost     float64
+	}{
+		{name: "session", report: report, wantAccuracy: 1.0 / 3, wantMistakes: 2, wantCost: 0.75},
+		{name: "player", report: report.Player(1), wantAccuracy: 0.5, wantMistakes: 1, wantCost: 0.25},
+		{name: "namesake", report: report.Player(2), wantAccuracy: 0, wantMistakes: 1, wantCost: 0.5},
+		{name: "nobody", report: report.Player(3), wantAccuracy: 1, wantMistakes: 0, wantCost: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.report.Accuracy(); math.Abs(got-tt.wantAccuracy) > 1e-9 {
+				t.Errorf("want %f, got %f", tt.wantAccuracy, got)
+			}
+
+			if got := len(tt.report.Mistakes()); got != tt.wantMistakes {
+				t.Errorf("want %d, got %d", tt.wantMistakes, got)
+			}
+
+			if got := tt.report.Cost(); got != tt.wantCost {
+				t.Errorf("want %f, got %f", tt.wantCost, got)
+			}
+		})
+	}
+}
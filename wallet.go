@@ -0,0 +1,129 @@
+package blackjack
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrInvalidAmount     = errors.New("invalid amount")
+)
+
+// Reason describes why money was moved from or to a Wallet.
+type Reason int
+
+const (
+	ReasonBet Reason = iota
+	ReasonDoubleDown
+	ReasonSplit
+	ReasonWin
+	ReasonBlackJack
+	ReasonPush
+	ReasonSurrender
+	ReasonSideBet
+	ReasonSideBetWin
+	ReasonBonus
+	ReasonRefund
+)
+
+func (r Reason) String() string {
+	switch r {
+	case ReasonBet:
+		return "bet"
+	case ReasonDoubleDown:
+		return "double down"
+	case ReasonSplit:
+		return "split"
+	case ReasonWin:
+		return "win"
+	case ReasonBlackJack:
+		return "black jack"
+	case ReasonPush:
+		return "push"
+	case ReasonSurrender:
+		return "surrender"
+	case ReasonSideBet:
+		return "side bet"
+	case ReasonSideBetWin:
+		return "side bet win"
+	case ReasonBonus:
+		return "bonus"
+	case ReasonRefund:
+		return "refund"
+	default:
+		return "unknown"
+	}
+}
+
+// Transaction is a single movement of money, tied to the round and the hand it belongs to.
+// Hand is 0 for the first hand of a player and 1 for the second hand after a split.
+type Transaction struct {
+	Round  int
+	Hand   int
+	Reason Reason
+	Amount Money
+}
+
+// Wallet holds the money of a Player. Implement it to plug in an own account system.
+type Wallet interface {
+	// Balance returns the money currently available.
+	Balance() Money
+	// Debit takes the amount of the transaction from the wallet.
+	// It should return ErrInsufficientFunds if the balance can not cover it.
+	Debit(tx Transaction) error
+	// Credit adds the amount of the transaction to the wallet.
+	Credit(tx Transaction) error
+}
+
+// MemoryWallet is the default Wallet which keeps the balance in memory.
+type MemoryWallet struct {
+	mu      sync.Mutex
+	balance Money
+}
+
+// Balance returns the current balance.
+func (w *MemoryWallet) Balance() Money {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.balance
+}
+
+// Debit takes the amount from the balance. It returns ErrInsufficientFunds if the balance is too low
+// and ErrInvalidAmount for a negative amount.
+func (w *MemoryWallet) Debit(tx Transaction) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if tx.Amount < 0 {
+		return ErrInvalidAmount
+	}
+
+	if tx.Amount > w.balance {
+		return ErrInsufficientFunds
+	}
+
+	w.balance -= tx.Amount
+	return nil
+}
+
+// Credit adds the amount to the balance. It returns ErrInvalidAmount for a negative amount.
+func (w *MemoryWallet) Credit(tx Transaction) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if tx.Amount < 0 {
+		return ErrInvalidAmount
+	}
+
+	w.balance += tx.Amount
+	return nil
+}
+
+// NewMemoryWallet creates a pointer to a MemoryWallet with the given balance.
+func NewMemoryWallet(balance Money) *MemoryWallet {
+	return &MemoryWallet{
+		balance: balance,
+	}
+}
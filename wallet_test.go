@@ -0,0 +1,99 @@
+package blackjack
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMemoryWallet_Debit(t *testing.T) {
+	tests := []struct {
+		name        string
+		balance     Money
+		amount      Money
+		wantErr     error
+		wantBalance Money
+	}{
+		{
+			name:        "debit correctly",
+			balance:     500,
+			amount:      200,
+			wantBalance: 300,
+		},
+		{
+			name:        "debit the whole balance",
+			balance:     500,
+			amount:      500,
+			wantBalance: 0,
+		},
+		{
+			name:        "insufficient funds",
+			balance:     500,
+			amount:      501,
+			wantErr:     ErrInsufficientFunds,
+			wantBalance: 500,
+		},
+		{
+			name:        "negative amount",
+			balance:     500,
+			amount:      -1,
+			wantErr:     ErrInvalidAmount,
+			wantBalance: 500,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewMemoryWallet(tt.balance)
+
+			err := w.Debit(Transaction{Reason: ReasonBet, Amount: tt.amount})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("want %#v, got %#v", tt.wantErr, err)
+			}
+
+			if got := w.Balance(); got != tt.wantBalance {
+				t.Errorf("want %d, got %d", tt.wantBalance, got)
+			}
+		})
+	}
+}
+
+func TestMemoryWallet_Credit(t *testing.T) {
+	tests := []struct {
+		name        string
+		balance     Money
+		amount      Money
+		wantErr     error
+		wantBalance Money
+	}{
+		{
+			name:        "credit correctly",
+			balance:     500,
+			amount:      200,
+			wantBalance: 700,
+		},
+		{
+			name:        "negative amount",
+			balance:     500,
+			amount:      -1,
+			wantErr:     ErrInvalidAmount,
+			wantBalance: 500,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewMemoryWallet(tt.balance)
+
+			err := w.Credit(Transaction{Reason: ReasonWin, Amount: tt.amount})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("want %#v, got %#v", tt.wantErr, err)
+			}
+
+			if got := w.Balance(); got != tt.wantBalance {
+				t.Errorf("want %d, got %d", tt.wantBalance, got)
+			}
+		})
+	}
+}